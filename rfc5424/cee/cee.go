@@ -0,0 +1,86 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package cee
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/rfc/v2/rfc5424"
+)
+
+// Cookie marks a MSG as holding a CEE JSON payload.
+const Cookie = "@cee:"
+
+// ErrNoCookie is returned by Decode when the MSG does not start with
+// the CEE cookie.
+var ErrNoCookie = errors.New("missing " + Cookie + " cookie")
+
+// Encode returns a MSG holding the fields as a CEE payload, i.e. the
+// cookie followed by a space and the JSON object. Field names must not
+// be empty.
+func Encode(fields map[string]interface{}) (string, error) {
+	for name := range fields {
+		if name == "" {
+			return "", errors.NotValidf("empty field name")
+		}
+	}
+	if fields == nil {
+		fields = map[string]interface{}{}
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return "", errors.Annotate(err, "encoding CEE payload")
+	}
+	return Cookie + " " + string(data), nil
+}
+
+// Decode returns the fields of the CEE payload in the MSG. Whitespace
+// is allowed between the cookie and the JSON, which must be a single
+// object. Numbers are returned as json.Number so that integers are
+// kept exactly.
+func Decode(body string) (map[string]interface{}, error) {
+	if !strings.HasPrefix(body, Cookie) {
+		return nil, ErrNoCookie
+	}
+	decoder := json.NewDecoder(strings.NewReader(body[len(Cookie):]))
+	decoder.UseNumber()
+
+	var fields map[string]interface{}
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, errors.NotValidf("CEE payload (%v)", err)
+	}
+	if fields == nil {
+		return nil, errors.NotValidf("CEE payload (null)")
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.NotValidf("CEE payload (trailing data)")
+	}
+	return fields, nil
+}
+
+// IsCEE reports whether the MSG starts with the CEE cookie.
+func IsCEE(body string) bool {
+	return strings.HasPrefix(body, Cookie)
+}
+
+// NewMessage returns a message with the header and the fields as its
+// CEE payload. The message is validated.
+func NewMessage(header rfc5424.Header, fields map[string]interface{}) (rfc5424.Message, error) {
+	body, err := Encode(fields)
+	if err != nil {
+		return rfc5424.Message{}, errors.Trace(err)
+	}
+	msg := rfc5424.Message{
+		Header: header,
+		Msg:    body,
+	}
+	if err := msg.Validate(); err != nil {
+		return msg, errors.NewNotValid(err, "message")
+	}
+	return msg, nil
+}
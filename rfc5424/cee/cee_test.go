@@ -0,0 +1,130 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package cee_test
+
+import (
+	"encoding/json"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/rfc/v2/rfc5424"
+	"github.com/juju/rfc/v2/rfc5424/cee"
+)
+
+type CEESuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&CEESuite{})
+
+func (s *CEESuite) TestEncode(c *gc.C) {
+	body, err := cee.Encode(map[string]interface{}{
+		"msg":    "started",
+		"pid":    119,
+		"nested": map[string]interface{}{"ok": true},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(body, gc.Equals, `@cee: {"msg":"started","nested":{"ok":true},"pid":119}`)
+}
+
+func (s *CEESuite) TestEncodeNil(c *gc.C) {
+	body, err := cee.Encode(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(body, gc.Equals, `@cee: {}`)
+}
+
+func (s *CEESuite) TestEncodeInvalid(c *gc.C) {
+	_, err := cee.Encode(map[string]interface{}{"": "x"})
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+
+	_, err = cee.Encode(map[string]interface{}{"ch": make(chan int)})
+	c.Check(err, gc.ErrorMatches, `encoding CEE payload: .*`)
+}
+
+func (s *CEESuite) TestDecode(c *gc.C) {
+	for _, body := range []string{
+		`@cee: {"msg":"started","pid":119}`,
+		`@cee:{"msg":"started","pid":119}`,
+		"@cee:\t{\"msg\":\"started\",\"pid\":119} ",
+	} {
+		c.Logf("body %q", body)
+
+		fields, err := cee.Decode(body)
+
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(fields, jc.DeepEquals, map[string]interface{}{
+			"msg": "started",
+			"pid": json.Number("119"),
+		})
+	}
+}
+
+func (s *CEESuite) TestDecodeInvalid(c *gc.C) {
+	for _, test := range []struct {
+		body string
+		err  string
+	}{{
+		body: `{"msg":"started"}`,
+		err:  `missing @cee: cookie`,
+	}, {
+		body: `@cee: not json`,
+		err:  `CEE payload \(.*\) not valid`,
+	}, {
+		body: `@cee: ["a","b"]`,
+		err:  `CEE payload \(.*cannot unmarshal array.*\) not valid`,
+	}, {
+		body: `@cee: null`,
+		err:  `CEE payload \(null\) not valid`,
+	}, {
+		body: `@cee: {} {}`,
+		err:  `CEE payload \(trailing data\) not valid`,
+	}, {
+		body: `@cee: {"a":1}}`,
+		err:  `CEE payload \(trailing data\) not valid`,
+	}, {
+		body: `@cee: {"a":1}]`,
+		err:  `CEE payload \(trailing data\) not valid`,
+	}} {
+		c.Logf("body %q", test.body)
+
+		_, err := cee.Decode(test.body)
+
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *CEESuite) TestIsCEE(c *gc.C) {
+	c.Check(cee.IsCEE(`@cee: {}`), jc.IsTrue)
+	c.Check(cee.IsCEE(`a message`), jc.IsFalse)
+}
+
+func (s *CEESuite) TestNewMessageRoundTrip(c *gc.C) {
+	header := rfc5424.Header{
+		Priority: rfc5424.Priority{
+			Severity: rfc5424.SeverityNotice,
+		},
+		AppName: "an-app",
+	}
+
+	msg, err := cee.NewMessage(header, map[string]interface{}{"event": "login", "user": "ümlaut"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(msg.Header, jc.DeepEquals, header)
+
+	parsed, err := rfc5424.ParseMessage(msg.String())
+	c.Assert(err, jc.ErrorIsNil)
+	fields, err := cee.Decode(parsed.Msg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fields, jc.DeepEquals, map[string]interface{}{"event": "login", "user": "ümlaut"})
+}
+
+func (s *CEESuite) TestNewMessageInvalidHeader(c *gc.C) {
+	_, err := cee.NewMessage(rfc5424.Header{AppName: "an app"}, nil)
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
@@ -0,0 +1,8 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+// The cee package supports the CEE log syntax (also known as
+// Lumberjack), where a syslog MSG holds a JSON object after an "@cee:"
+// cookie. This is what rsyslog's mmjsonparse module parses into
+// structured fields.
+package cee
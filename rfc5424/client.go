@@ -55,6 +55,44 @@ func TLSDialFunc(cfg *tls.Config, timeout time.Duration) (DialFunc, error) {
 	return dial, nil
 }
 
+// Sender is implemented by anything that syslog messages can be sent
+// to, such as a Client.
+type Sender interface {
+	// Send sends the syslog message.
+	Send(Message) error
+}
+
+// RawSender is implemented by senders, such as a Client, that can send
+// a parsed message exactly as it was received.
+type RawSender interface {
+	// SendRaw sends the parsed message; see RawMessage.Verbatim.
+	SendRaw(*RawMessage) error
+}
+
+// SendRaw sends the parsed message with the sender's SendRaw method if
+// it is a RawSender, and otherwise sends just raw.Message.
+func SendRaw(sender Sender, raw *RawMessage) error {
+	if rs, ok := sender.(RawSender); ok {
+		return rs.SendRaw(raw)
+	}
+	return sender.Send(raw.Message)
+}
+
+// Matcher is implemented by anything that selects syslog messages,
+// such as a filter from the filter package.
+type Matcher interface {
+	// Match reports whether the message is selected.
+	Match(Message) bool
+}
+
+// MatcherFunc is a Matcher that is implemented as a function.
+type MatcherFunc func(Message) bool
+
+// Match returns f(msg).
+func (f MatcherFunc) Match(msg Message) bool {
+	return f(msg)
+}
+
 // ClientConfig is the configuration for a syslog client.
 type ClientConfig struct {
 	// MaxSize is the maximum allowed size for syslog messages sent
@@ -63,14 +101,31 @@ type ClientConfig struct {
 
 	// SendTImeout is the timeout that is used for each sent message.
 	SendTimeout time.Duration
+
+	// Framing is how messages are delimited on stream connections
+	// (TCP, TLS and Unix stream sockets). It is ignored for UDP and
+	// unixgram.
+	Framing Framing
+
+	// Defaults is applied to each message before it is sent, filling
+	// in any empty fields. See MessageTemplate.Apply.
+	Defaults MessageTemplate
+
+	// Filter, if set, selects the messages that are sent. Other
+	// messages are dropped without error. The filter sees messages
+	// after the defaults have been applied.
+	Filter Matcher
 }
 
 // Client is a wrapper around a network connection to which syslog
 // messages will be sent.
 type Client struct {
-	maxSize int
-	timeout time.Duration
-	conn    Conn
+	maxSize  int
+	timeout  time.Duration
+	framing  Framing
+	defaults MessageTemplate
+	filter   Matcher
+	conn     Conn
 }
 
 // Open opens a syslog client to the given host address. If no dial
@@ -85,9 +140,12 @@ func Open(host string, cfg ClientConfig, dial DialFunc) (*Client, error) {
 	}
 
 	client := &Client{
-		maxSize: cfg.MaxSize,
-		timeout: cfg.SendTimeout,
-		conn:    conn,
+		maxSize:  cfg.MaxSize,
+		timeout:  cfg.SendTimeout,
+		framing:  cfg.Framing,
+		defaults: cfg.Defaults,
+		filter:   cfg.Filter,
+		conn:     conn,
 	}
 	return client, nil
 }
@@ -98,28 +156,49 @@ func (client Client) Close() error {
 	return errors.Trace(err)
 }
 
-// Send sends the syslog message over the client's connection.
+// Send sends the syslog message over the client's connection, after
+// applying the client's defaults. Messages that the client's filter
+// does not select are dropped.
 func (client Client) Send(msg Message) error {
-	data := client.serialize(msg)
-	if err := client.send(data); err != nil {
-		return errors.Trace(err)
+	msg = client.defaults.Apply(msg)
+	if client.filter != nil && !client.filter.Match(msg) {
+		return nil
 	}
-	return nil
+	return errors.Trace(client.send(client.serialize(msg.String())))
 }
 
-func (client Client) serialize(msg Message) []byte {
-	msgStr := msg.String()
-	if client.maxSize > 0 && len(msgStr) > client.maxSize {
-		msgStr = msgStr[:client.maxSize]
+// SendRaw implements RawSender. The parsed message is sent exactly as
+// it was received, unless it has been changed other than by appending
+// structured data (see RawMessage.Verbatim). Only the structured data
+// of the client's defaults is applied to it (see
+// MessageTemplate.ApplyRaw). Messages that the client's filter does
+// not select are dropped.
+func (client Client) SendRaw(raw *RawMessage) error {
+	raw = client.defaults.ApplyRaw(raw)
+	if client.filter != nil && !client.filter.Match(raw.Message) {
+		return nil
 	}
+	return errors.Trace(client.send(client.serialize(raw.String())))
+}
 
-	switch client.conn.(type) {
+func (client Client) serialize(str string) []byte {
+	data := []byte(str)
+	if client.maxSize > 0 && len(data) > client.maxSize {
+		data = data[:client.maxSize]
+	}
+
+	switch conn := client.conn.(type) {
 	case *net.TCPConn, *tls.Conn:
-		msgStr += "\n"
+		data = client.framing.Frame(data)
+	case *net.UnixConn:
+		// Only stream sockets need framing, not unixgram.
+		if conn.RemoteAddr().Network() == "unix" {
+			data = client.framing.Frame(data)
+		}
 	case *net.UDPConn:
 		// For now do nothing.
 	}
-	return []byte(msgStr)
+	return data
 }
 
 func (client Client) send(msg []byte) error {
@@ -6,6 +6,7 @@ package rfc5424_test
 import (
 	"time"
 
+	"github.com/juju/clock/testclock"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -94,6 +95,72 @@ func (s *ClientSuite) TestSend(c *gc.C) {
 	s.stub.CheckCallNames(c, "SetWriteDeadline", "Write")
 }
 
+func (s *ClientSuite) TestSendDefaults(c *gc.C) {
+	var cfg rfc5424.ClientConfig
+	cfg.Defaults.Facility = rfc5424.FacilityDaemon
+	cfg.Defaults.Hostname = rfc5424.Hostname{FQDN: "a.b.org"}
+	cfg.Defaults.AppName = "an-app"
+	cfg.Defaults.Clock = testclock.NewClock(time.Unix(54321, 123).UTC())
+	client, err := rfc5424.Open("a.b.c:1234", cfg, s.dial)
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.ResetCalls()
+	msg := rfc5424.Message{
+		Header: rfc5424.Header{
+			Priority: rfc5424.Priority{
+				Severity: rfc5424.SeverityWarning,
+			},
+			ProcID: "119",
+		},
+		Msg: "a message",
+	}
+
+	err = client.Send(msg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Write")
+	s.stub.CheckCall(c, 0, "Write", `<28>1 1970-01-01T15:05:21.000000123Z a.b.org an-app 119 - - a message`)
+}
+
+func (s *ClientSuite) TestSendVerbatim(c *gc.C) {
+	client, err := rfc5424.Open("a.b.c:1234", rfc5424.ClientConfig{}, s.dial)
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.ResetCalls()
+	text := `<28>1 1970-01-01T16:05:21+01:00 a.b.org an-app 119 - [spam x="\y"] a message`
+	raw, err := rfc5424.ParseRawMessage(text)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = client.SendRaw(raw)
+	c.Assert(err, jc.ErrorIsNil)
+	err = client.Send(raw.Message)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Write", "Write")
+	s.stub.CheckCall(c, 0, "Write", text)
+	s.stub.CheckCall(c, 1, "Write", raw.Message.String())
+}
+
+func (s *ClientSuite) TestSendFiltered(c *gc.C) {
+	var cfg rfc5424.ClientConfig
+	cfg.Defaults.Facility = rfc5424.FacilityDaemon
+	cfg.Filter = rfc5424.MatcherFunc(func(msg rfc5424.Message) bool {
+		return msg.Facility == rfc5424.FacilityDaemon && msg.Severity <= rfc5424.SeverityWarning
+	})
+	client, err := rfc5424.Open("a.b.c:1234", cfg, s.dial)
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.ResetCalls()
+	msg := rfc5424.Message{Msg: "a message"}
+	msg.Severity = rfc5424.SeverityDebug
+
+	err = client.Send(msg)
+	c.Assert(err, jc.ErrorIsNil)
+	msg.Severity = rfc5424.SeverityWarning
+	err = client.Send(msg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Write")
+	s.stub.CheckCall(c, 0, "Write", `<28>1 - - - - - - a message`)
+}
+
 type stubConn struct {
 	stub *testing.Stub
 
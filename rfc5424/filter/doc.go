@@ -0,0 +1,40 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+// The filter package selects syslog messages using a small filter
+// language modelled on rsyslog's. A filter is made up of terms
+// separated by spaces, all of which must match:
+//
+//	*.err;auth,authpriv.none app-name=juju [origin software=juju]
+//
+// A selector term is a list of syslog.conf selectors separated by
+// semicolons. Each selector is a comma-separated list of facility names
+// (or "*" for all of them), a dot and a severity. A severity selects
+// messages at least that severe; "=" selects only that severity, "*"
+// selects all severities and "none" selects none. A "!" before the
+// severity removes it from the selection instead. Later selectors
+// override earlier ones, so "*.info;mail.none" selects informational
+// messages from every facility except mail.
+//
+// A property term compares a header field or the MSG with a value:
+//
+//	app-name=juju     equal
+//	hostname!=a.b.org not equal
+//	msgid^=ID         starts with
+//	msg*=denied       contains
+//	procid~=^[0-9]+$  matches the regular expression
+//
+// The properties are app-name, hostname, procid, msgid and msg. A
+// value containing spaces may be given as a double-quoted Go string.
+// A NILVALUE field has the empty value.
+//
+// A structured data term, in square brackets, matches messages with an
+// element with the given SD-ID. Any params listed must also be present,
+// with the given value where there is one:
+//
+//	[origin]
+//	[origin software=juju swVersion]
+//
+// A property or structured data term preceded by "!" matches messages
+// that the term without it does not.
+package filter
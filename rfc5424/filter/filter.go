@@ -0,0 +1,172 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package filter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/rfc/v2/rfc5424"
+)
+
+// term is a single part of a filter.
+type term interface {
+	match(msg rfc5424.Message) bool
+}
+
+// Filter is a parsed filter expression. The zero value matches every
+// message.
+type Filter struct {
+	expr  string
+	terms []term
+}
+
+var _ rfc5424.Matcher = Filter{}
+
+// Parse parses the filter expression, as described in the package
+// documentation.
+func Parse(expr string) (Filter, error) {
+	strs, err := splitTerms(expr)
+	if err != nil {
+		return Filter{}, errors.NotValidf("filter %q (%v)", expr, err)
+	}
+	if len(strs) == 0 {
+		return Filter{}, errors.NotValidf("empty filter")
+	}
+	f := Filter{expr: expr}
+	for _, str := range strs {
+		t, err := parseTerm(str)
+		if err != nil {
+			return Filter{}, errors.NotValidf("filter term %q (%v)", str, err)
+		}
+		f.terms = append(f.terms, t)
+	}
+	return f, nil
+}
+
+// MustParse is like Parse but panics if the expression is not valid.
+func MustParse(expr string) Filter {
+	f, err := Parse(expr)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// Match implements rfc5424.Matcher.
+func (f Filter) Match(msg rfc5424.Message) bool {
+	for _, t := range f.terms {
+		if !t.match(msg) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the expression the filter was parsed from.
+func (f Filter) String() string {
+	return f.expr
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (f Filter) MarshalText() ([]byte, error) {
+	return []byte(f.expr), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Empty text gives
+// the zero value, which matches every message.
+func (f *Filter) UnmarshalText(text []byte) error {
+	if strings.TrimSpace(string(text)) == "" {
+		*f = Filter{}
+		return nil
+	}
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	*f = parsed
+	return nil
+}
+
+// not inverts a term.
+type not struct {
+	term
+}
+
+func (n not) match(msg rfc5424.Message) bool {
+	return !n.term.match(msg)
+}
+
+func parseTerm(str string) (term, error) {
+	negated := strings.HasPrefix(str, "!")
+	body := strings.TrimPrefix(str, "!")
+
+	var t term
+	var err error
+	switch {
+	case strings.HasPrefix(body, "["):
+		t, err = parseSDTerm(body)
+	case isPropertyTerm(body):
+		t, err = parsePropertyTerm(body)
+	case negated:
+		return nil, fmt.Errorf("only property and structured data terms may be negated")
+	default:
+		t, err = parseSelectors(body)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if negated {
+		t = not{t}
+	}
+	return t, nil
+}
+
+// splitTerms splits the expression at spaces that are not in a
+// quoted string or in the square brackets of a structured data term.
+func splitTerms(expr string) ([]string, error) {
+	var terms []string
+	var current strings.Builder
+	inQuotes := false
+	inBrackets := false
+	for i := 0; i < len(expr); i++ {
+		c := expr[i]
+		switch {
+		case inQuotes:
+			if c == '\\' && i+1 < len(expr) {
+				current.WriteByte(c)
+				i++
+				c = expr[i]
+			} else if c == '"' {
+				inQuotes = false
+			}
+		case c == '"':
+			inQuotes = true
+		case c == '[' && (current.Len() == 0 || current.String() == "!"):
+			inBrackets = true
+		case c == ']' && inBrackets:
+			inBrackets = false
+		case !inBrackets && unicode.IsSpace(rune(c)):
+			if current.Len() > 0 {
+				terms = append(terms, current.String())
+				current.Reset()
+			}
+			continue
+		}
+		current.WriteByte(c)
+	}
+	if inQuotes {
+		return nil, fmt.Errorf("unterminated quoted string")
+	}
+	if inBrackets {
+		return nil, fmt.Errorf("missing %q", "]")
+	}
+	if current.Len() > 0 {
+		terms = append(terms, current.String())
+	}
+	return terms, nil
+}
@@ -0,0 +1,194 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package filter_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/rfc/v2/rfc5424"
+	"github.com/juju/rfc/v2/rfc5424/filter"
+	"github.com/juju/rfc/v2/rfc5424/sdelements"
+)
+
+type FilterSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FilterSuite{})
+
+func newMessage(fac rfc5424.Facility, sev rfc5424.Severity) rfc5424.Message {
+	return rfc5424.Message{
+		Header: rfc5424.Header{
+			Priority: rfc5424.Priority{
+				Severity: sev,
+				Facility: fac,
+			},
+			Hostname: rfc5424.Hostname{FQDN: "a.b.org"},
+			AppName:  "juju",
+			ProcID:   "119",
+			MsgID:    "ID47",
+		},
+		StructuredData: rfc5424.StructuredData{
+			sdelements.Origin{
+				EnterpriseID: sdelements.OriginEnterpriseID{Number: 28978},
+				SoftwareName: "juju",
+			},
+			rfc5424.GenericStructuredDataElement{
+				SDID: "spam@32473",
+				Data: []rfc5424.StructuredDataParam{
+					{Name: "x", Value: "y"},
+					{Name: "x", Value: "a b"},
+				},
+			},
+		},
+		Msg: "permission denied",
+	}
+}
+
+func (s *FilterSuite) TestSelectors(c *gc.C) {
+	for i, test := range []struct {
+		expr    string
+		fac     rfc5424.Facility
+		sev     rfc5424.Severity
+		matches bool
+	}{
+		{"*.err", rfc5424.FacilityMail, rfc5424.SeverityError, true},
+		{"*.err", rfc5424.FacilityMail, rfc5424.SeverityCrit, true},
+		{"*.err", rfc5424.FacilityMail, rfc5424.SeverityWarning, false},
+		{"*.*", rfc5424.FacilityLocal7, rfc5424.SeverityDebug, true},
+		{"mail.info", rfc5424.FacilityMail, rfc5424.SeverityInformational, true},
+		{"mail.info", rfc5424.FacilityAuth, rfc5424.SeverityInformational, false},
+		{"user.info", 0, rfc5424.SeverityInformational, true},
+		{"*.err;auth,authpriv.none", rfc5424.FacilityAuth, rfc5424.SeverityEmergency, false},
+		{"*.err;auth,authpriv.none", rfc5424.FacilityAuthpriv, rfc5424.SeverityError, false},
+		{"*.err;auth,authpriv.none", rfc5424.FacilityDaemon, rfc5424.SeverityError, true},
+		{"*.err;local3.=debug", rfc5424.FacilityLocal3, rfc5424.SeverityDebug, true},
+		{"*.err;local3.=debug", rfc5424.FacilityLocal3, rfc5424.SeverityInformational, false},
+		{"*.err;local3.=debug", rfc5424.FacilityLocal3, rfc5424.SeverityAlert, true},
+		{"mail.*;mail.!err", rfc5424.FacilityMail, rfc5424.SeverityError, false},
+		{"mail.*;mail.!err", rfc5424.FacilityMail, rfc5424.SeverityWarning, true},
+		{"mail.*;mail.!=info", rfc5424.FacilityMail, rfc5424.SeverityInformational, false},
+		{"mail.*;mail.!=info", rfc5424.FacilityMail, rfc5424.SeverityDebug, true},
+		{"MAIL.Warn", rfc5424.FacilityMail, rfc5424.SeverityWarning, true},
+	} {
+		c.Logf("test %d: %q %s.%s", i, test.expr, test.fac, test.sev)
+		f, err := filter.Parse(test.expr)
+		c.Assert(err, jc.ErrorIsNil)
+
+		c.Check(f.Match(newMessage(test.fac, test.sev)), gc.Equals, test.matches)
+	}
+}
+
+func (s *FilterSuite) TestTerms(c *gc.C) {
+	for i, test := range []struct {
+		expr    string
+		matches bool
+	}{
+		{"app-name=juju", true},
+		{"app-name=jujud", false},
+		{"app-name!=jujud", true},
+		{"hostname^=a.b", true},
+		{"hostname=", false},
+		{"procid~=^[0-9]+$", true},
+		{"procid~=^[a-z]+$", false},
+		{"msgid*=D4", true},
+		{`msg="permission denied"`, true},
+		{`msg*="ion d"`, true},
+		{"!msg*=denied", false},
+		{"[origin]", true},
+		{"[meta]", false},
+		{"![meta]", true},
+		{"[origin software=juju]", true},
+		{"[origin software=juju enterpriseID]", true},
+		{"[origin software=jujud]", false},
+		{"[origin swVersion]", false},
+		{`[spam@32473 x="a b"]`, true},
+		{"[spam@32473 x=y]", true},
+		{"daemon.warning app-name=juju [origin software=juju]", true},
+		{"daemon.err app-name=juju [origin software=juju]", false},
+	} {
+		c.Logf("test %d: %q", i, test.expr)
+		f, err := filter.Parse(test.expr)
+		c.Assert(err, jc.ErrorIsNil)
+
+		c.Check(f.Match(newMessage(rfc5424.FacilityDaemon, rfc5424.SeverityWarning)), gc.Equals, test.matches)
+	}
+}
+
+func (s *FilterSuite) TestParseErrors(c *gc.C) {
+	for i, test := range []struct {
+		expr string
+		err  string
+	}{{
+		expr: "",
+		err:  `empty filter not valid`,
+	}, {
+		expr: "*",
+		err:  `filter term "\*" \(selector "\*" missing severity\) not valid`,
+	}, {
+		expr: "spam.err",
+		err:  `.*unknown facility "spam".*`,
+	}, {
+		expr: "*.spam",
+		err:  `.*unknown severity "spam".*`,
+	}, {
+		expr: "*.=none",
+		err:  `.*unknown severity "none".*`,
+	}, {
+		expr: "!*.err",
+		err:  `.*only property and structured data terms may be negated.*`,
+	}, {
+		expr: `msg="denied`,
+		err:  `filter .* \(unterminated quoted string\) not valid`,
+	}, {
+		expr: `msg=de"nied"`,
+		err:  `.*unexpected quote.*`,
+	}, {
+		expr: "procid~=[",
+		err:  `.*bad regular expression.*`,
+	}, {
+		expr: "[origin",
+		err:  `filter .* \(missing "]"\) not valid`,
+	}, {
+		expr: "[origin] [spam",
+		err:  `filter .* \(missing "]"\) not valid`,
+	}, {
+		expr: "[]",
+		err:  `.*missing SD-ID.*`,
+	}, {
+		expr: "[ori=gin]",
+		err:  `.*bad SD-ID.*`,
+	}} {
+		c.Logf("test %d: %q", i, test.expr)
+		_, err := filter.Parse(test.expr)
+
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *FilterSuite) TestZeroValue(c *gc.C) {
+	var f filter.Filter
+
+	c.Check(f.Match(newMessage(rfc5424.FacilityMail, rfc5424.SeverityDebug)), jc.IsTrue)
+}
+
+func (s *FilterSuite) TestText(c *gc.C) {
+	var f filter.Filter
+	err := f.UnmarshalText([]byte("*.err;auth.none"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(f.String(), gc.Equals, "*.err;auth.none")
+	c.Check(f.Match(newMessage(rfc5424.FacilityMail, rfc5424.SeverityError)), jc.IsTrue)
+	c.Check(f.Match(newMessage(rfc5424.FacilityAuth, rfc5424.SeverityError)), jc.IsFalse)
+	text, err := f.MarshalText()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(text), gc.Equals, "*.err;auth.none")
+
+	err = f.UnmarshalText([]byte("*.spam"))
+	c.Check(err, gc.ErrorMatches, `.*unknown severity.*`)
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/juju/rfc/v2/rfc5424"
+)
+
+// properties maps each property name to a func that returns its value.
+var properties = map[string]func(rfc5424.Message) string{
+	"app-name": func(msg rfc5424.Message) string { return string(msg.AppName) },
+	"hostname": func(msg rfc5424.Message) string {
+		hostname := msg.Hostname.String()
+		if hostname == "-" {
+			return ""
+		}
+		return hostname
+	},
+	"procid": func(msg rfc5424.Message) string { return string(msg.ProcID) },
+	"msgid":  func(msg rfc5424.Message) string { return string(msg.MsgID) },
+	"msg":    func(msg rfc5424.Message) string { return msg.Msg },
+}
+
+// operators lists the comparison operators. Those that are prefixed
+// by others come first.
+var operators = []string{"!=", "^=", "*=", "~=", "="}
+
+// propertyTerm compares a property with a value.
+type propertyTerm struct {
+	value   func(rfc5424.Message) string
+	compare func(string) bool
+}
+
+func (t propertyTerm) match(msg rfc5424.Message) bool {
+	return t.compare(t.value(msg))
+}
+
+// isPropertyTerm reports whether the term starts with the name of a
+// property followed by an operator.
+func isPropertyTerm(str string) bool {
+	name, _, _ := splitProperty(str)
+	return name != ""
+}
+
+// splitProperty splits a property term into the property name, the
+// operator and the rest. An empty name is returned if the term is not
+// a property term.
+func splitProperty(str string) (string, string, string) {
+	for name := range properties {
+		if !strings.HasPrefix(str, name) {
+			continue
+		}
+		rest := str[len(name):]
+		for _, op := range operators {
+			if strings.HasPrefix(rest, op) {
+				return name, op, rest[len(op):]
+			}
+		}
+	}
+	return "", "", ""
+}
+
+func parsePropertyTerm(str string) (propertyTerm, error) {
+	name, op, valueStr := splitProperty(str)
+	value, err := parseValue(valueStr)
+	if err != nil {
+		return propertyTerm{}, err
+	}
+
+	t := propertyTerm{value: properties[name]}
+	switch op {
+	case "=":
+		t.compare = func(s string) bool { return s == value }
+	case "!=":
+		t.compare = func(s string) bool { return s != value }
+	case "^=":
+		t.compare = func(s string) bool { return strings.HasPrefix(s, value) }
+	case "*=":
+		t.compare = func(s string) bool { return strings.Contains(s, value) }
+	case "~=":
+		re, err := regexp.Compile(value)
+		if err != nil {
+			return propertyTerm{}, fmt.Errorf("bad regular expression: %v", err)
+		}
+		t.compare = re.MatchString
+	}
+	return t, nil
+}
+
+// parseValue returns the value, which may be a double-quoted Go string.
+func parseValue(str string) (string, error) {
+	if !strings.HasPrefix(str, `"`) {
+		if strings.Contains(str, `"`) {
+			return "", fmt.Errorf("unexpected quote in %q", str)
+		}
+		return str, nil
+	}
+	value, err := strconv.Unquote(str)
+	if err != nil {
+		return "", fmt.Errorf("bad quoted value %s", str)
+	}
+	return value, nil
+}
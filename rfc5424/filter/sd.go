@@ -0,0 +1,86 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package filter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/rfc/v2/rfc5424"
+)
+
+// sdParam is a param that an element must have. If hasValue is false
+// then any value matches.
+type sdParam struct {
+	name     rfc5424.StructuredDataName
+	value    string
+	hasValue bool
+}
+
+// sdTerm matches messages with a structured data element that has the
+// SD-ID and all of the params.
+type sdTerm struct {
+	id     rfc5424.StructuredDataName
+	params []sdParam
+}
+
+func (t sdTerm) match(msg rfc5424.Message) bool {
+	for _, element := range msg.StructuredData {
+		if element.ID() == t.id && t.matchParams(element.Params()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t sdTerm) matchParams(params []rfc5424.StructuredDataParam) bool {
+	for _, want := range t.params {
+		found := false
+		for _, param := range params {
+			if param.Name == want.name && (!want.hasValue || string(param.Value) == want.value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func parseSDTerm(str string) (sdTerm, error) {
+	if !strings.HasSuffix(str, "]") {
+		return sdTerm{}, fmt.Errorf("missing %q", "]")
+	}
+	parts, err := splitTerms(str[1 : len(str)-1])
+	if err != nil {
+		return sdTerm{}, err
+	}
+	if len(parts) == 0 {
+		return sdTerm{}, fmt.Errorf("missing SD-ID")
+	}
+
+	t := sdTerm{id: rfc5424.StructuredDataName(parts[0])}
+	if err := t.id.Validate(); err != nil {
+		return sdTerm{}, fmt.Errorf("bad SD-ID: %v", err)
+	}
+	for _, part := range parts[1:] {
+		name, valueStr, hasValue := strings.Cut(part, "=")
+		param := sdParam{
+			name:     rfc5424.StructuredDataName(name),
+			hasValue: hasValue,
+		}
+		if err := param.name.Validate(); err != nil {
+			return sdTerm{}, fmt.Errorf("bad param name %q: %v", name, err)
+		}
+		if hasValue {
+			if param.value, err = parseValue(valueStr); err != nil {
+				return sdTerm{}, err
+			}
+		}
+		t.params = append(t.params, param)
+	}
+	return t, nil
+}
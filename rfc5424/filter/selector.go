@@ -0,0 +1,115 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package filter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/rfc/v2/rfc5424"
+)
+
+// severityMask has a bit set for each selected severity.
+type severityMask uint8
+
+// allSeverities selects every severity.
+const allSeverities severityMask = 0xff
+
+// selectorTerm is a list of syslog.conf selectors, reduced to the
+// severities selected for each facility. The zero facility, which is
+// the default, is handled as "user".
+type selectorTerm struct {
+	masks [rfc5424.FacilityLocal7 + 1]severityMask
+}
+
+func (t *selectorTerm) match(msg rfc5424.Message) bool {
+	fac := msg.Facility
+	if fac == 0 {
+		fac = rfc5424.FacilityUser
+	}
+	if fac < 0 || int(fac) >= len(t.masks) {
+		return false
+	}
+	return t.masks[fac]&(1<<uint(msg.Severity)) != 0
+}
+
+func parseSelectors(str string) (*selectorTerm, error) {
+	t := &selectorTerm{}
+	for _, selector := range strings.Split(str, ";") {
+		if err := t.add(selector); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// add applies a single selector, as syslogd does: a selected severity
+// is added to what earlier selectors selected for the facility, "none"
+// clears it and a negated severity is removed from it.
+func (t *selectorTerm) add(selector string) error {
+	dot := strings.LastIndexByte(selector, '.')
+	if dot < 0 {
+		return fmt.Errorf("selector %q missing severity", selector)
+	}
+	facilities, err := parseFacilities(selector[:dot])
+	if err != nil {
+		return fmt.Errorf("selector %q: %v", selector, err)
+	}
+
+	sevStr := selector[dot+1:]
+	negated := strings.HasPrefix(sevStr, "!")
+	sevStr = strings.TrimPrefix(sevStr, "!")
+	exact := strings.HasPrefix(sevStr, "=")
+	sevStr = strings.TrimPrefix(sevStr, "=")
+
+	var mask severityMask
+	switch {
+	case sevStr == "none" && !negated && !exact:
+		for _, fac := range facilities {
+			t.masks[fac] = 0
+		}
+		return nil
+	case sevStr == "*" && !exact:
+		mask = allSeverities
+	default:
+		sev, err := rfc5424.ParseSeverity(sevStr)
+		if err != nil {
+			return fmt.Errorf("selector %q: %v", selector, err)
+		}
+		if exact {
+			mask = 1 << uint(sev)
+		} else {
+			// Lower severity values are more severe.
+			mask = 1<<uint(sev+1) - 1
+		}
+	}
+
+	for _, fac := range facilities {
+		if negated {
+			t.masks[fac] &^= mask
+		} else {
+			t.masks[fac] |= mask
+		}
+	}
+	return nil
+}
+
+func parseFacilities(str string) ([]rfc5424.Facility, error) {
+	if str == "*" {
+		var all []rfc5424.Facility
+		for fac := rfc5424.FacilityKern; fac <= rfc5424.FacilityLocal7; fac++ {
+			all = append(all, fac)
+		}
+		return all, nil
+	}
+	var facilities []rfc5424.Facility
+	for _, name := range strings.Split(str, ",") {
+		fac, err := rfc5424.ParseFacility(name)
+		if err != nil {
+			return nil, err
+		}
+		facilities = append(facilities, fac)
+	}
+	return facilities, nil
+}
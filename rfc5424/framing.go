@@ -0,0 +1,148 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package rfc5424
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strconv"
+
+	"github.com/juju/errors"
+)
+
+// ErrFrameTooLarge is returned by FrameReader.ReadFrame when a frame
+// exceeds the reader's maximum size.
+var ErrFrameTooLarge = errors.New("syslog frame too large")
+
+// maxFrameLengthDigits is the number of digits allowed in the length
+// prefix of an octet-counted frame.
+const maxFrameLengthDigits = 10
+
+// Framing identifies how syslog messages are delimited when they are
+// sent over a stream transport such as TCP or TLS.
+//
+// See https://tools.ietf.org/html/rfc6587#section-3.4.
+type Framing int
+
+const (
+	// FramingNonTransparent terminates each message with a LF. This
+	// is the traditional framing and the default.
+	FramingNonTransparent Framing = iota
+
+	// FramingOctetCounting prefixes each message with its length in
+	// octets and a space, as required by RFC 5425.
+	FramingOctetCounting
+)
+
+// String returns the name of the framing.
+func (f Framing) String() string {
+	switch f {
+	case FramingNonTransparent:
+		return "non-transparent"
+	case FramingOctetCounting:
+		return "octet-counting"
+	default:
+		return "Framing " + strconv.Itoa(int(f))
+	}
+}
+
+// Frame returns a copy of the message with the framing applied.
+func (f Framing) Frame(msg []byte) []byte {
+	switch f {
+	case FramingOctetCounting:
+		prefix := strconv.Itoa(len(msg)) + " "
+		return append([]byte(prefix), msg...)
+	default:
+		framed := make([]byte, len(msg), len(msg)+1)
+		copy(framed, msg)
+		return append(framed, '\n')
+	}
+}
+
+// FrameReader reads framed syslog messages from a stream. The framing
+// of each message is detected from its first octet, so a stream may
+// mix both framings, as RFC 6587 receivers are expected to handle.
+type FrameReader struct {
+	r       *bufio.Reader
+	maxSize int
+}
+
+// NewFrameReader returns a FrameReader that reads from r. Frames
+// larger than maxSize result in ErrFrameTooLarge. If maxSize is not
+// positive then there is no maximum.
+func NewFrameReader(r io.Reader, maxSize int) *FrameReader {
+	return &FrameReader{
+		r:       bufio.NewReader(r),
+		maxSize: maxSize,
+	}
+}
+
+// ReadFrame returns the next message, without its framing, along with
+// the framing that was used. At the end of the stream io.EOF is
+// returned. If the stream ends part way through a frame then the data
+// read so far is returned with io.ErrUnexpectedEOF.
+func (fr *FrameReader) ReadFrame() ([]byte, Framing, error) {
+	first, err := fr.r.Peek(1)
+	if err != nil {
+		return nil, FramingNonTransparent, err
+	}
+	if first[0] >= '1' && first[0] <= '9' {
+		msg, err := fr.readOctetCounted()
+		return msg, FramingOctetCounting, err
+	}
+	msg, err := fr.readNonTransparent()
+	return msg, FramingNonTransparent, err
+}
+
+func (fr *FrameReader) readOctetCounted() ([]byte, error) {
+	prefix, err := fr.r.ReadSlice(' ')
+	if err == bufio.ErrBufferFull || len(prefix) > maxFrameLengthDigits+1 {
+		return nil, errors.New("octet count too long")
+	}
+	if err == io.EOF {
+		return nil, io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	size, err := strconv.Atoi(string(prefix[:len(prefix)-1]))
+	if err != nil {
+		return nil, errors.Errorf("bad octet count %q", prefix[:len(prefix)-1])
+	}
+	if fr.maxSize > 0 && size > fr.maxSize {
+		return nil, ErrFrameTooLarge
+	}
+
+	msg := make([]byte, size)
+	n, err := io.ReadFull(fr.r, msg)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return msg[:n], err
+}
+
+func (fr *FrameReader) readNonTransparent() ([]byte, error) {
+	var msg []byte
+	for {
+		chunk, err := fr.r.ReadSlice('\n')
+		msg = append(msg, chunk...)
+		if err == nil {
+			msg = bytes.TrimSuffix(msg[:len(msg)-1], []byte("\r"))
+		}
+		if fr.maxSize > 0 && len(msg) > fr.maxSize {
+			return nil, ErrFrameTooLarge
+		}
+		switch err {
+		case nil:
+			return msg, nil
+		case bufio.ErrBufferFull:
+			continue
+		case io.EOF:
+			return msg, io.ErrUnexpectedEOF
+		default:
+			return msg, errors.Trace(err)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package rfc5424_test
+
+import (
+	"io"
+	"strings"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/rfc/v2/rfc5424"
+)
+
+type FramingSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FramingSuite{})
+
+func (s *FramingSuite) TestFrameNonTransparent(c *gc.C) {
+	framed := rfc5424.FramingNonTransparent.Frame([]byte("<8>1 - - - - - -"))
+
+	c.Check(string(framed), gc.Equals, "<8>1 - - - - - -\n")
+}
+
+func (s *FramingSuite) TestFrameOctetCounting(c *gc.C) {
+	framed := rfc5424.FramingOctetCounting.Frame([]byte("<8>1 - - - - - -"))
+
+	c.Check(string(framed), gc.Equals, "16 <8>1 - - - - - -")
+}
+
+func (s *FramingSuite) TestReadFrameMixed(c *gc.C) {
+	stream := "<8>1 - - - - - - one\n20 <8>1 - - - - - - a\nb<8>1 - - - - - - three\r\n"
+	reader := rfc5424.NewFrameReader(strings.NewReader(stream), 0)
+
+	frame, framing, err := reader.ReadFrame()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(frame), gc.Equals, "<8>1 - - - - - - one")
+	c.Check(framing, gc.Equals, rfc5424.FramingNonTransparent)
+
+	frame, framing, err = reader.ReadFrame()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(frame), gc.Equals, "<8>1 - - - - - - a\nb")
+	c.Check(framing, gc.Equals, rfc5424.FramingOctetCounting)
+
+	frame, framing, err = reader.ReadFrame()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(frame), gc.Equals, "<8>1 - - - - - - three")
+	c.Check(framing, gc.Equals, rfc5424.FramingNonTransparent)
+
+	_, _, err = reader.ReadFrame()
+	c.Check(err, gc.Equals, io.EOF)
+}
+
+func (s *FramingSuite) TestReadFrameTooLarge(c *gc.C) {
+	reader := rfc5424.NewFrameReader(strings.NewReader("100 <8>1"), 50)
+	_, _, err := reader.ReadFrame()
+	c.Check(err, gc.Equals, rfc5424.ErrFrameTooLarge)
+
+	reader = rfc5424.NewFrameReader(strings.NewReader(strings.Repeat("x", 51)+"\n"), 50)
+	_, _, err = reader.ReadFrame()
+	c.Check(err, gc.Equals, rfc5424.ErrFrameTooLarge)
+}
+
+func (s *FramingSuite) TestReadFramePartial(c *gc.C) {
+	reader := rfc5424.NewFrameReader(strings.NewReader("20 <8>1 - -"), 0)
+	frame, _, err := reader.ReadFrame()
+	c.Check(err, gc.Equals, io.ErrUnexpectedEOF)
+	c.Check(string(frame), gc.Equals, "<8>1 - -")
+
+	reader = rfc5424.NewFrameReader(strings.NewReader("<8>1 - -"), 0)
+	frame, _, err = reader.ReadFrame()
+	c.Check(err, gc.Equals, io.ErrUnexpectedEOF)
+	c.Check(string(frame), gc.Equals, "<8>1 - -")
+}
+
+func (s *FramingSuite) TestReadFrameBadOctetCount(c *gc.C) {
+	reader := rfc5424.NewFrameReader(strings.NewReader("12345678901234 <8>1"), 0)
+	_, _, err := reader.ReadFrame()
+	c.Check(err, gc.ErrorMatches, "octet count too long")
+
+	reader = rfc5424.NewFrameReader(strings.NewReader("12x <8>1"), 0)
+	_, _, err = reader.ReadFrame()
+	c.Check(err, gc.ErrorMatches, `bad octet count "12x"`)
+}
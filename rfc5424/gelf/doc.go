@@ -0,0 +1,9 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+// The gelf package converts RFC 5424 syslog messages to and from the
+// Graylog Extended Log Format (GELF) 1.1, and sends them to Graylog
+// over UDP, in chunks where necessary.
+//
+// See https://go2docs.graylog.org/current/getting_in_log_data/gelf.html.
+package gelf
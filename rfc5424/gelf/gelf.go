@@ -0,0 +1,288 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package gelf
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/rfc/v2/rfc5424"
+)
+
+// Version is the GELF version that is produced.
+const Version = "1.1"
+
+// These are the additional fields used for the parts of the header that
+// GELF has no field for.
+const (
+	FieldAppName  = "_app_name"
+	FieldProcID   = "_procid"
+	FieldMsgID    = "_msgid"
+	FieldFacility = "_facility"
+)
+
+// DecodedSDID is the SD-ID of the element that holds the additional
+// fields of a decoded message.
+const DecodedSDID rfc5424.StructuredDataName = "gelf"
+
+// maxNameLength is the longest allowed SD-NAME.
+const maxNameLength = 32
+
+// Encode returns the GELF JSON for the message. The first line of Msg
+// is the short_message and, if there is more than one line, all of Msg
+// is the full_message. An empty Msg gives a short_message of "-",
+// since GELF requires one.
+//
+// Each structured data param becomes an additional field named
+// "_<SD-ID>_<name>", with any characters that GELF does not allow
+// replaced by "_". Values of params with the same field name are
+// joined with ",".
+func Encode(msg rfc5424.Message) ([]byte, error) {
+	fields := map[string]interface{}{
+		"version": Version,
+		"host":    msg.Hostname.String(),
+		"level":   int(msg.Severity - rfc5424.SeverityEmergency),
+	}
+
+	short := msg.Msg
+	if i := strings.IndexAny(short, "\r\n"); i >= 0 {
+		short = short[:i]
+		fields["full_message"] = msg.Msg
+	}
+	if short == "" {
+		short = "-"
+	}
+	fields["short_message"] = short
+
+	if !msg.Timestamp.IsZero() {
+		fields["timestamp"] = json.Number(formatTimestamp(msg.Timestamp.Time))
+	}
+	if msg.AppName != "" {
+		fields[FieldAppName] = string(msg.AppName)
+	}
+	if msg.ProcID != "" {
+		fields[FieldProcID] = string(msg.ProcID)
+	}
+	if msg.MsgID != "" {
+		fields[FieldMsgID] = string(msg.MsgID)
+	}
+	if text, err := msg.Facility.MarshalText(); err == nil {
+		fields[FieldFacility] = string(text)
+	}
+
+	for _, sde := range msg.StructuredData {
+		for _, param := range sde.Params() {
+			name := fieldName(string(sde.ID()), string(param.Name))
+			if existing, ok := fields[name].(string); ok {
+				fields[name] = existing + "," + string(param.Value)
+			} else {
+				fields[name] = string(param.Value)
+			}
+		}
+	}
+
+	data, err := json.Marshal(fields)
+	return data, errors.Trace(err)
+}
+
+func formatTimestamp(t time.Time) string {
+	return strconv.FormatFloat(float64(t.UnixNano())/1e9, 'f', 6, 64)
+}
+
+func fieldName(id, name string) string {
+	return "_" + strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		case r == '_', r == '.', r == '-':
+			return r
+		default:
+			return '_'
+		}
+	}, id+"_"+name)
+}
+
+// DefaultMaxSize is the largest message, after decompression, that
+// Decode accepts.
+const DefaultMaxSize = 16 << 20
+
+// ErrTooLarge is returned when a compressed message decompresses to
+// more than the maximum size.
+var ErrTooLarge = errors.New("decompressed message too large")
+
+// Decode converts GELF JSON, optionally compressed with gzip or zlib,
+// back into a message. Messages that decompress to more than
+// DefaultMaxSize bytes result in ErrTooLarge. The full_message is used
+// for Msg if there is one, and short_message otherwise. A missing level
+// is ALERT, as the specification requires.
+//
+// Additional fields other than the ones for the header become params
+// of a single element with SD-ID DecodedSDID, in order of name. The
+// leading "_" is dropped from their names, which are truncated to the
+// 32 characters allowed, and numbers are formatted in decimal. A field
+// whose truncated name is the same as that of an earlier one is
+// dropped.
+func Decode(data []byte) (rfc5424.Message, error) {
+	msg, err := DecodeMax(data, DefaultMaxSize)
+	return msg, errors.Trace(err)
+}
+
+// DecodeMax is like Decode, but messages that decompress to more than
+// maxSize bytes result in ErrTooLarge. If maxSize is not positive then
+// there is no maximum.
+func DecodeMax(data []byte, maxSize int) (rfc5424.Message, error) {
+	var msg rfc5424.Message
+
+	data, err := decompress(data, maxSize)
+	if err != nil {
+		return msg, errors.Trace(err)
+	}
+
+	var fields map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&fields); err != nil {
+		return msg, errors.NotValidf("GELF JSON (%v)", err)
+	}
+
+	if _, ok := fields["version"].(string); !ok {
+		return msg, errors.NotValidf("missing version")
+	}
+	host, ok := fields["host"].(string)
+	if !ok {
+		return msg, errors.NotValidf("missing host")
+	}
+	msg.Hostname = rfc5424.ParseHostname(host)
+	short, ok := fields["short_message"].(string)
+	if !ok {
+		return msg, errors.NotValidf("missing short_message")
+	}
+	msg.Msg = short
+	if full, ok := fields["full_message"].(string); ok && full != "" {
+		msg.Msg = full
+	}
+
+	msg.Severity = rfc5424.SeverityAlert
+	if level, ok := fields["level"].(json.Number); ok {
+		code, err := level.Int64()
+		if err != nil || code < 0 || code > 7 {
+			return msg, errors.NotValidf("level %v", level)
+		}
+		msg.Severity = rfc5424.SeverityEmergency + rfc5424.Severity(code)
+	}
+	if timestamp, ok := fields["timestamp"].(json.Number); ok {
+		secs, err := timestamp.Float64()
+		if err != nil {
+			return msg, errors.NotValidf("timestamp %v", timestamp)
+		}
+		whole, frac := math.Modf(secs)
+		msg.Timestamp = rfc5424.Timestamp{Time: time.Unix(int64(whole), int64(math.Round(frac*1e6))*1e3).UTC()}
+	}
+
+	if appName, ok := fields[FieldAppName].(string); ok {
+		msg.AppName = rfc5424.AppName(appName)
+	}
+	if procID, ok := fields[FieldProcID].(string); ok {
+		msg.ProcID = rfc5424.ProcID(procID)
+	}
+	if msgID, ok := fields[FieldMsgID].(string); ok {
+		msg.MsgID = rfc5424.MsgID(msgID)
+	}
+	if name, ok := fields[FieldFacility].(string); ok {
+		fac, err := rfc5424.ParseFacility(name)
+		if err != nil {
+			return msg, errors.NotValidf("%s (%v)", FieldFacility, err)
+		}
+		msg.Facility = fac
+	}
+
+	if params := additionalParams(fields); len(params) > 0 {
+		msg.StructuredData = rfc5424.StructuredData{
+			rfc5424.GenericStructuredDataElement{
+				SDID: DecodedSDID,
+				Data: params,
+			},
+		}
+	}
+
+	if err := msg.Validate(); err != nil {
+		return msg, errors.NewNotValid(err, "decoded message")
+	}
+	return msg, nil
+}
+
+func additionalParams(fields map[string]interface{}) []rfc5424.StructuredDataParam {
+	var names []string
+	for name := range fields {
+		switch name {
+		case FieldAppName, FieldProcID, FieldMsgID, FieldFacility, "_id":
+		default:
+			if strings.HasPrefix(name, "_") && len(name) > 1 {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+
+	var params []rfc5424.StructuredDataParam
+	seen := make(map[string]bool)
+	for _, name := range names {
+		paramName := name[1:]
+		if len(paramName) > maxNameLength {
+			paramName = paramName[:maxNameLength]
+		}
+		if seen[paramName] {
+			continue
+		}
+		seen[paramName] = true
+		params = append(params, rfc5424.StructuredDataParam{
+			Name:  rfc5424.StructuredDataName(paramName),
+			Value: rfc5424.StructuredDataParamValue(fmt.Sprint(fields[name])),
+		})
+	}
+	return params
+}
+
+// decompress returns the data uncompressed, detecting gzip and zlib by
+// their magic bytes. Only up to maxSize bytes are decompressed, if it
+// is positive.
+func decompress(data []byte, maxSize int) ([]byte, error) {
+	var r io.ReadCloser
+	var err error
+	switch {
+	case len(data) >= 2 && data[0] == 0x1f && data[1] == 0x8b:
+		r, err = gzip.NewReader(bytes.NewReader(data))
+	case len(data) >= 2 && data[0] == 0x78:
+		r, err = zlib.NewReader(bytes.NewReader(data))
+	default:
+		return data, nil
+	}
+	if err != nil {
+		return nil, errors.Annotate(err, "decompressing")
+	}
+	defer r.Close()
+	var limited io.Reader = r
+	if maxSize > 0 {
+		limited = io.LimitReader(r, int64(maxSize)+1)
+	}
+	data, err = io.ReadAll(limited)
+	if err != nil {
+		return nil, errors.Annotate(err, "decompressing")
+	}
+	if maxSize > 0 && len(data) > maxSize {
+		return nil, ErrTooLarge
+	}
+	return data, nil
+}
@@ -0,0 +1,213 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package gelf_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/rfc/v2/rfc5424"
+	"github.com/juju/rfc/v2/rfc5424/gelf"
+)
+
+type GELFSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&GELFSuite{})
+
+func newMessage() rfc5424.Message {
+	return rfc5424.Message{
+		Header: rfc5424.Header{
+			Priority: rfc5424.Priority{
+				Severity: rfc5424.SeverityWarning,
+				Facility: rfc5424.FacilityDaemon,
+			},
+			Timestamp: rfc5424.Timestamp{Time: time.Unix(54321, 123000).UTC()},
+			Hostname:  rfc5424.Hostname{FQDN: "a.b.org"},
+			AppName:   "an-app",
+			ProcID:    "119",
+			MsgID:     "xyz...",
+		},
+		StructuredData: rfc5424.StructuredData{
+			rfc5424.GenericStructuredDataElement{
+				SDID: "spam@32473",
+				Data: []rfc5424.StructuredDataParam{
+					{Name: "x", Value: "y"},
+					{Name: "x", Value: "z"},
+				},
+			},
+		},
+		Msg: "a message\nwith details",
+	}
+}
+
+const messageGELF = `{` +
+	`"_app_name":"an-app",` +
+	`"_facility":"daemon",` +
+	`"_msgid":"xyz...",` +
+	`"_procid":"119",` +
+	`"_spam_32473_x":"y,z",` +
+	`"full_message":"a message\nwith details",` +
+	`"host":"a.b.org",` +
+	`"level":4,` +
+	`"short_message":"a message",` +
+	`"timestamp":54321.000123,` +
+	`"version":"1.1"` +
+	`}`
+
+func (s *GELFSuite) TestEncode(c *gc.C) {
+	data, err := gelf.Encode(newMessage())
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(string(data), gc.Equals, messageGELF)
+}
+
+func (s *GELFSuite) TestEncodeMinimal(c *gc.C) {
+	data, err := gelf.Encode(rfc5424.Message{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(string(data), gc.Equals, `{"_facility":"user","host":"-","level":0,"short_message":"-","version":"1.1"}`)
+}
+
+func (s *GELFSuite) TestDecode(c *gc.C) {
+	msg, err := gelf.Decode([]byte(messageGELF))
+	c.Assert(err, jc.ErrorIsNil)
+
+	expected := newMessage()
+	expected.StructuredData = rfc5424.StructuredData{
+		rfc5424.GenericStructuredDataElement{
+			SDID: gelf.DecodedSDID,
+			Data: []rfc5424.StructuredDataParam{
+				{Name: "spam_32473_x", Value: "y,z"},
+			},
+		},
+	}
+	c.Check(msg, jc.DeepEquals, expected)
+}
+
+func (s *GELFSuite) TestDecodeCompressed(c *gc.C) {
+	var gzipped, zlibbed bytes.Buffer
+	gw := gzip.NewWriter(&gzipped)
+	gw.Write([]byte(messageGELF))
+	gw.Close()
+	zw := zlib.NewWriter(&zlibbed)
+	zw.Write([]byte(messageGELF))
+	zw.Close()
+
+	for _, data := range [][]byte{gzipped.Bytes(), zlibbed.Bytes()} {
+		msg, err := gelf.Decode(data)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(msg.Msg, gc.Equals, "a message\nwith details")
+	}
+}
+
+func (s *GELFSuite) TestDecodeTooLarge(c *gc.C) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write([]byte(`{"version":"1.1","host":"example","short_message":"` + strings.Repeat("x", 1000) + `"}`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w.Close(), jc.ErrorIsNil)
+
+	_, err = gelf.DecodeMax(buf.Bytes(), 100)
+	c.Check(errors.Cause(err), gc.Equals, gelf.ErrTooLarge)
+
+	msg, err := gelf.DecodeMax(buf.Bytes(), 2000)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(msg.Msg, gc.HasLen, 1000)
+}
+
+func (s *GELFSuite) TestDecodeDefaults(c *gc.C) {
+	msg, err := gelf.Decode([]byte(`{"version":"1.1","host":"example","short_message":"hi","_count":3,"_id":"x"}`))
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(msg, jc.DeepEquals, rfc5424.Message{
+		Header: rfc5424.Header{
+			Priority: rfc5424.Priority{
+				Severity: rfc5424.SeverityAlert,
+			},
+			Hostname: rfc5424.Hostname{Hostname: "example"},
+		},
+		StructuredData: rfc5424.StructuredData{
+			rfc5424.GenericStructuredDataElement{
+				SDID: gelf.DecodedSDID,
+				Data: []rfc5424.StructuredDataParam{
+					{Name: "count", Value: "3"},
+				},
+			},
+		},
+		Msg: "hi",
+	})
+}
+
+func (s *GELFSuite) TestDecodeLongNames(c *gc.C) {
+	long := strings.Repeat("x", 32)
+	msg, err := gelf.Decode([]byte(`{"version":"1.1","host":"example","short_message":"hi",` +
+		`"_` + long + `":1,"_` + long + `a":2,"_` + long + `b":3}`))
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(msg.StructuredData, jc.DeepEquals, rfc5424.StructuredData{
+		rfc5424.GenericStructuredDataElement{
+			SDID: gelf.DecodedSDID,
+			Data: []rfc5424.StructuredDataParam{
+				{Name: rfc5424.StructuredDataName(long), Value: "1"},
+			},
+		},
+	})
+}
+
+func (s *GELFSuite) TestDecodeInvalid(c *gc.C) {
+	for _, test := range []struct {
+		data string
+		err  string
+	}{{
+		data: `not json`,
+		err:  `GELF JSON \(.*\) not valid`,
+	}, {
+		data: `{"host":"example","short_message":"hi"}`,
+		err:  `missing version not valid`,
+	}, {
+		data: `{"version":"1.1","short_message":"hi"}`,
+		err:  `missing host not valid`,
+	}, {
+		data: `{"version":"1.1","host":"example"}`,
+		err:  `missing short_message not valid`,
+	}, {
+		data: `{"version":"1.1","host":"example","short_message":"hi","level":8}`,
+		err:  `level 8 not valid`,
+	}, {
+		data: `{"version":"1.1","host":"example","short_message":"hi","_facility":"nowhere"}`,
+		err:  `_facility \(unknown facility "nowhere"\) not valid`,
+	}} {
+		c.Logf("data %s", test.data)
+
+		_, err := gelf.Decode([]byte(test.data))
+
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *GELFSuite) TestEncodeIsJSON(c *gc.C) {
+	msg := newMessage()
+	msg.Msg = `quotes " and \ backslashes`
+
+	data, err := gelf.Encode(msg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var fields map[string]interface{}
+	c.Assert(json.Unmarshal(data, &fields), jc.ErrorIsNil)
+	c.Check(fields["short_message"], gc.Equals, msg.Msg)
+	_, ok := fields["full_message"]
+	c.Check(ok, jc.IsFalse)
+}
@@ -0,0 +1,216 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package gelf
+
+import (
+	"bytes"
+	"crypto/rand"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/rfc/v2/rfc5424"
+)
+
+const (
+	// DefaultChunkSize is the largest datagram that UDPSender sends by
+	// default. It fits in the MTU of most networks, including WANs.
+	DefaultChunkSize = 1420
+
+	// MaxChunks is the largest number of chunks that a message may be
+	// split into.
+	MaxChunks = 128
+
+	// ChunkTimeout is how long Assembler waits for all the chunks of
+	// a message to arrive.
+	ChunkTimeout = 5 * time.Second
+
+	// DefaultMaxPending is the number of incomplete messages that an
+	// Assembler created by NewAssembler holds at once.
+	DefaultMaxPending = 1000
+
+	chunkHeaderSize = 12
+)
+
+var chunkMagic = []byte{0x1e, 0x0f}
+
+// ErrTooManyChunks is returned when a message needs more than
+// MaxChunks chunks.
+var ErrTooManyChunks = errors.New("message needs too many chunks")
+
+// ErrTooManyPending is returned by Assembler.Add when a chunk starts a
+// new message while the assembler already holds its maximum number of
+// incomplete messages.
+var ErrTooManyPending = errors.New("too many incomplete messages")
+
+// Chunk splits the data into datagrams of at most chunkSize bytes. If
+// the data fits in one datagram it is returned as is, and otherwise
+// each chunk has the GELF chunk header with a random message ID.
+func Chunk(data []byte, chunkSize int) ([][]byte, error) {
+	if len(data) <= chunkSize {
+		return [][]byte{data}, nil
+	}
+	payloadSize := chunkSize - chunkHeaderSize
+	if payloadSize <= 0 {
+		return nil, errors.NotValidf("chunk size %d", chunkSize)
+	}
+	count := (len(data) + payloadSize - 1) / payloadSize
+	if count > MaxChunks {
+		return nil, ErrTooManyChunks
+	}
+
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, errors.Trace(err)
+	}
+	chunks := make([][]byte, count)
+	for i := range chunks {
+		payload := data[i*payloadSize:]
+		if len(payload) > payloadSize {
+			payload = payload[:payloadSize]
+		}
+		chunk := make([]byte, 0, chunkHeaderSize+len(payload))
+		chunk = append(chunk, chunkMagic...)
+		chunk = append(chunk, id...)
+		chunk = append(chunk, byte(i), byte(count))
+		chunks[i] = append(chunk, payload...)
+	}
+	return chunks, nil
+}
+
+// UDPSender is an rfc5424.Sender that sends GELF messages to Graylog
+// over UDP, uncompressed.
+type UDPSender struct {
+	conn      net.Conn
+	chunkSize int
+}
+
+var _ rfc5424.Sender = (*UDPSender)(nil)
+
+// DialUDP returns a sender that sends to the given address. If
+// chunkSize is zero then DefaultChunkSize is used.
+func DialUDP(address string, chunkSize int) (*UDPSender, error) {
+	conn, err := net.Dial("udp", address)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewUDPSender(conn, chunkSize), nil
+}
+
+// NewUDPSender returns a sender that writes to the connection, which
+// should preserve datagram boundaries. If chunkSize is zero then
+// DefaultChunkSize is used.
+func NewUDPSender(conn net.Conn, chunkSize int) *UDPSender {
+	if chunkSize == 0 {
+		chunkSize = DefaultChunkSize
+	}
+	return &UDPSender{
+		conn:      conn,
+		chunkSize: chunkSize,
+	}
+}
+
+// Send implements rfc5424.Sender.
+func (s *UDPSender) Send(msg rfc5424.Message) error {
+	data, err := Encode(msg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	chunks, err := Chunk(data, s.chunkSize)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, chunk := range chunks {
+		if _, err := s.conn.Write(chunk); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// Close closes the sender's connection.
+func (s *UDPSender) Close() error {
+	return errors.Trace(s.conn.Close())
+}
+
+// Assembler puts chunked GELF datagrams back together. Incomplete
+// messages are discarded after ChunkTimeout.
+type Assembler struct {
+	// MaxPending limits the number of incomplete messages held at
+	// once. NewAssembler sets this to DefaultMaxPending; zero means
+	// no limit.
+	MaxPending int
+
+	mu      sync.Mutex
+	pending map[string]*pendingMessage
+}
+
+type pendingMessage struct {
+	started time.Time
+	chunks  [][]byte
+	missing int
+}
+
+// NewAssembler returns a new Assembler.
+func NewAssembler() *Assembler {
+	return &Assembler{
+		MaxPending: DefaultMaxPending,
+		pending:    make(map[string]*pendingMessage),
+	}
+}
+
+// Add adds a datagram. If the datagram completes a message then the
+// message's data is returned, and otherwise nil. Datagrams that are
+// not chunked are returned as is.
+func (a *Assembler) Add(datagram []byte) ([]byte, error) {
+	if !bytes.HasPrefix(datagram, chunkMagic) {
+		return datagram, nil
+	}
+	if len(datagram) < chunkHeaderSize {
+		return nil, errors.NotValidf("chunk of %d bytes", len(datagram))
+	}
+	id := string(datagram[2:10])
+	seq, count := int(datagram[10]), int(datagram[11])
+	if count == 0 || count > MaxChunks || seq >= count {
+		return nil, errors.NotValidf("chunk %d of %d", seq, count)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := time.Now()
+	for pendingID, msg := range a.pending {
+		if now.Sub(msg.started) > ChunkTimeout {
+			delete(a.pending, pendingID)
+		}
+	}
+
+	msg, ok := a.pending[id]
+	if !ok {
+		if a.MaxPending > 0 && len(a.pending) >= a.MaxPending {
+			return nil, ErrTooManyPending
+		}
+		msg = &pendingMessage{
+			started: now,
+			chunks:  make([][]byte, count),
+			missing: count,
+		}
+		a.pending[id] = msg
+	}
+	if len(msg.chunks) != count {
+		return nil, errors.NotValidf("chunk count %d, expected %d", count, len(msg.chunks))
+	}
+	if msg.chunks[seq] == nil {
+		msg.chunks[seq] = make([]byte, len(datagram)-chunkHeaderSize)
+		copy(msg.chunks[seq], datagram[chunkHeaderSize:])
+		msg.missing--
+	}
+	if msg.missing > 0 {
+		return nil, nil
+	}
+	delete(a.pending, id)
+	return bytes.Join(msg.chunks, nil), nil
+}
@@ -0,0 +1,126 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package gelf_test
+
+import (
+	"bytes"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/rfc/v2/rfc5424/gelf"
+)
+
+type UDPSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&UDPSuite{})
+
+func (s *UDPSuite) TestChunkSmall(c *gc.C) {
+	chunks, err := gelf.Chunk([]byte("hello"), 100)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(chunks, jc.DeepEquals, [][]byte{[]byte("hello")})
+}
+
+func (s *UDPSuite) TestChunkAndAssemble(c *gc.C) {
+	data := bytes.Repeat([]byte("0123456789"), 10)
+
+	chunks, err := gelf.Chunk(data, 42)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(chunks, gc.HasLen, 4)
+	for i, chunk := range chunks {
+		c.Check(len(chunk) <= 42, jc.IsTrue)
+		c.Check(chunk[:2], jc.DeepEquals, []byte{0x1e, 0x0f})
+		c.Check(chunk[2:10], jc.DeepEquals, chunks[0][2:10])
+		c.Check(int(chunk[10]), gc.Equals, i)
+		c.Check(int(chunk[11]), gc.Equals, 4)
+	}
+
+	// Chunks may arrive out of order and more than once.
+	a := gelf.NewAssembler()
+	for _, i := range []int{2, 0, 2, 3} {
+		assembled, err := a.Add(chunks[i])
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(assembled, gc.IsNil)
+	}
+	assembled, err := a.Add(chunks[1])
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(assembled, jc.DeepEquals, data)
+}
+
+func (s *UDPSuite) TestChunkTooMany(c *gc.C) {
+	_, err := gelf.Chunk(make([]byte, 129*10), 22)
+
+	c.Check(errors.Cause(err), gc.Equals, gelf.ErrTooManyChunks)
+}
+
+func (s *UDPSuite) TestAssembleBadChunk(c *gc.C) {
+	a := gelf.NewAssembler()
+
+	_, err := a.Add([]byte{0x1e, 0x0f, 1, 2, 3, 4, 5, 6, 7, 8, 3, 3})
+
+	c.Check(err, gc.ErrorMatches, `chunk 3 of 3 not valid`)
+}
+
+func (s *UDPSuite) TestAssembleTooManyPending(c *gc.C) {
+	a := gelf.NewAssembler()
+	a.MaxPending = 2
+	chunk := func(id byte) []byte {
+		return []byte{0x1e, 0x0f, id, 2, 3, 4, 5, 6, 7, 8, 0, 2, 'x'}
+	}
+
+	for _, id := range []byte{1, 2} {
+		_, err := a.Add(chunk(id))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	_, err := a.Add(chunk(3))
+	c.Check(err, gc.Equals, gelf.ErrTooManyPending)
+
+	// Chunks of messages already pending are still accepted.
+	last := chunk(1)
+	last[10] = 1
+	assembled, err := a.Add(last)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(assembled), gc.Equals, "xx")
+	_, err = a.Add(chunk(3))
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *UDPSuite) TestSend(c *gc.C) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	defer pc.Close()
+	sender, err := gelf.DialUDP(pc.LocalAddr().String(), 200)
+	c.Assert(err, jc.ErrorIsNil)
+	defer sender.Close()
+
+	msg := newMessage()
+	msg.Msg = strings.Repeat("a long message ", 100)
+	err = sender.Send(msg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	a := gelf.NewAssembler()
+	buf := make([]byte, 1024)
+	pc.SetReadDeadline(time.Now().Add(10 * time.Second))
+	var assembled []byte
+	for assembled == nil {
+		n, _, err := pc.ReadFrom(buf)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(n <= 200, jc.IsTrue)
+		assembled, err = a.Add(buf[:n])
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	received, err := gelf.Decode(assembled)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(received.Msg, gc.Equals, msg.Msg)
+	c.Check(received.AppName, gc.Equals, msg.AppName)
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package rfc5424
+
+import (
+	"encoding/binary"
+	"net"
+	"syscall"
+)
+
+// ifaFlags is the IFA_FLAGS address attribute, which holds the full
+// flags when they do not fit in the header.
+const ifaFlags = 8
+
+// interfaceIPs returns the first global unicast address of the local
+// machine that the kernel holds as permanent, and the first that it
+// does not, if any. Addresses assigned by DHCP or autoconfiguration
+// have a lifetime, so they are not permanent.
+func interfaceIPs() (static, dynamic net.IP) {
+	data, err := syscall.NetlinkRIB(syscall.RTM_GETADDR, syscall.AF_UNSPEC)
+	if err != nil {
+		return nil, nil
+	}
+	msgs, err := syscall.ParseNetlinkMessage(data)
+	if err != nil {
+		return nil, nil
+	}
+	for _, msg := range msgs {
+		if msg.Header.Type != syscall.RTM_NEWADDR || len(msg.Data) < syscall.SizeofIfAddrmsg {
+			continue
+		}
+		flags := uint32(msg.Data[2])
+		attrs, err := syscall.ParseNetlinkRouteAttr(&msg)
+		if err != nil {
+			continue
+		}
+		var ip net.IP
+		for _, attr := range attrs {
+			switch attr.Attr.Type {
+			case syscall.IFA_LOCAL:
+				ip = net.IP(attr.Value)
+			case syscall.IFA_ADDRESS:
+				if ip == nil {
+					ip = net.IP(attr.Value)
+				}
+			case ifaFlags:
+				if len(attr.Value) == 4 {
+					flags = binary.NativeEndian.Uint32(attr.Value)
+				}
+			}
+		}
+		if !ip.IsGlobalUnicast() {
+			continue
+		}
+		if flags&syscall.IFA_F_PERMANENT != 0 {
+			if static == nil {
+				static = ip
+			}
+		} else if dynamic == nil {
+			dynamic = ip
+		}
+	}
+	return static, dynamic
+}
@@ -0,0 +1,24 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+//go:build !linux
+
+package rfc5424
+
+import "net"
+
+// interfaceIPs returns the first global unicast address of the local
+// machine, if any, as a dynamic address, since there is no portable
+// way to tell how it was assigned.
+func interfaceIPs() (static, dynamic net.IP) {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return nil, nil
+	}
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok && ipNet.IP.IsGlobalUnicast() {
+			return nil, ipNet.IP
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,447 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package httpsyslog
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+
+	"github.com/juju/rfc/v2/rfc5424"
+)
+
+// batch is a request body of octet-counted messages.
+type batch struct {
+	// seq numbers the batches in the order they are queued.
+	seq int
+
+	body  []byte
+	count int
+
+	// started is when the first message was added.
+	started time.Time
+}
+
+// Client is an rfc5424.Sender that posts syslog messages to an HTTP
+// endpoint in batches. Messages are posted in the order they were
+// sent, in the background; use Flush to wait for them. Client is safe
+// for concurrent use.
+type Client struct {
+	cfg   Config
+	clock clock.Clock
+
+	mu      sync.Mutex
+	closed  bool
+	current *batch
+	queue   []*batch
+
+	// queued and finished are the sequence numbers of the last batch
+	// queued and the last batch posted or dropped.
+	queued   int
+	finished int
+
+	// lastErr is the error of the last batch dropped, which was
+	// lastErrSeq.
+	lastErr    error
+	lastErrSeq int
+
+	// changed is closed, and replaced, whenever a batch is finished.
+	changed chan struct{}
+
+	wake    chan struct{}
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+var (
+	_ rfc5424.Sender    = (*Client)(nil)
+	_ rfc5424.RawSender = (*Client)(nil)
+)
+
+// New returns a client for the configured URL. Nothing is posted until
+// messages are sent.
+func New(cfg Config) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	cfg = cfg.withDefaults()
+	client := &Client{
+		cfg:     cfg,
+		clock:   cfg.Clock,
+		current: &batch{},
+		changed: make(chan struct{}),
+		wake:    make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go client.loop()
+	return client, nil
+}
+
+// Send implements rfc5424.Sender. The message is added to the current
+// batch after applying the client's defaults, unless the client's
+// filter does not select it. Send blocks while the queue of batches
+// waiting to be posted is full.
+func (client *Client) Send(msg rfc5424.Message) error {
+	msg = client.cfg.Defaults.Apply(msg)
+	if client.cfg.Filter != nil && !client.cfg.Filter.Match(msg) {
+		return nil
+	}
+	return errors.Trace(client.add(msg.String()))
+}
+
+// SendRaw implements rfc5424.RawSender. The parsed message is added as
+// for Send, but as it was received (see rfc5424.RawMessage.Verbatim),
+// with only the structured data of the client's defaults added.
+func (client *Client) SendRaw(raw *rfc5424.RawMessage) error {
+	raw = client.cfg.Defaults.ApplyRaw(raw)
+	if client.cfg.Filter != nil && !client.cfg.Filter.Match(raw.Message) {
+		return nil
+	}
+	return errors.Trace(client.add(raw.String()))
+}
+
+// add adds the message text to the current batch.
+func (client *Client) add(str string) error {
+	data := []byte(str)
+	if client.cfg.MaxSize > 0 && len(data) > client.cfg.MaxSize {
+		data = data[:client.cfg.MaxSize]
+	}
+	frame := rfc5424.FramingOctetCounting.Frame(data)
+
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	var timeout <-chan time.Time
+	if client.cfg.SendTimeout > 0 {
+		timer := time.NewTimer(client.cfg.SendTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	for {
+		if client.closed {
+			return errors.New("HTTP client closed")
+		}
+		current := client.current
+		full := current.count >= client.cfg.MaxBatchMessages ||
+			(current.count > 0 && len(current.body)+len(frame) > client.cfg.MaxBatchBytes)
+		if !full {
+			break
+		}
+		if client.queueFull() {
+			if err := client.wait(timeout); err != nil {
+				return errors.Annotate(err, "waiting for room in queue")
+			}
+			continue
+		}
+		client.queueCurrent()
+	}
+
+	current := client.current
+	if current.count == 0 {
+		current.started = client.clock.Now()
+		client.notify()
+	}
+	current.body = append(current.body, frame...)
+	current.count++
+	if current.count >= client.cfg.MaxBatchMessages && !client.queueFull() {
+		client.queueCurrent()
+	}
+	return nil
+}
+
+// Flush waits until every message sent so far has been posted, or
+// dropped after failing to post it. The error of the last batch that
+// was dropped in the meantime is returned.
+func (client *Client) Flush() error {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	return errors.Trace(client.flush())
+}
+
+// Close posts any messages not yet posted, then stops the client.
+// Batches that fail are not retried once Close is called. The error
+// of the last batch that was dropped while closing is returned.
+func (client *Client) Close() error {
+	client.mu.Lock()
+	if client.closed {
+		client.mu.Unlock()
+		return nil
+	}
+	client.closed = true
+	start := client.finished
+	client.mu.Unlock()
+
+	close(client.stop)
+	<-client.stopped
+
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	if client.lastErrSeq > start {
+		return errors.Trace(client.lastErr)
+	}
+	return nil
+}
+
+// flush is called with the mutex held.
+func (client *Client) flush() error {
+	start := client.finished
+	for client.current.count > 0 {
+		if client.queueFull() {
+			client.wait(nil)
+			continue
+		}
+		client.queueCurrent()
+	}
+	for client.finished < client.queued {
+		client.wait(nil)
+	}
+	if client.lastErrSeq > start {
+		return client.lastErr
+	}
+	return nil
+}
+
+func (client *Client) queueFull() bool {
+	return len(client.queue) >= client.cfg.MaxQueuedBatches
+}
+
+// queueCurrent queues the current batch to be posted and starts a new
+// one.
+func (client *Client) queueCurrent() {
+	client.queued++
+	client.current.seq = client.queued
+	client.queue = append(client.queue, client.current)
+	client.current = &batch{}
+	client.notify()
+}
+
+// notify wakes the loop.
+func (client *Client) notify() {
+	select {
+	case client.wake <- struct{}{}:
+	default:
+	}
+}
+
+// wait waits, without holding the mutex, for a batch to be finished.
+func (client *Client) wait(timeout <-chan time.Time) error {
+	changed := client.changed
+	client.mu.Unlock()
+	defer client.mu.Lock()
+	select {
+	case <-changed:
+		return nil
+	case <-timeout:
+		return errors.New("timed out")
+	}
+}
+
+// loop posts batches until the client is closed and has nothing left
+// to post.
+func (client *Client) loop() {
+	defer close(client.stopped)
+	for {
+		b, wait, done := client.next()
+		if done {
+			return
+		}
+		if b != nil {
+			err := client.post(b)
+			client.finish(b, err)
+			continue
+		}
+		client.sleep(wait)
+	}
+}
+
+// sleep waits to be woken or stopped, or for the duration if it is
+// not 0.
+func (client *Client) sleep(wait time.Duration) {
+	var expired <-chan time.Time
+	if wait > 0 {
+		timer := client.clock.NewTimer(wait)
+		defer timer.Stop()
+		expired = timer.Chan()
+	}
+	select {
+	case <-client.stop:
+	case <-client.wake:
+	case <-expired:
+	}
+}
+
+// next returns the next batch to post. If there is none, it returns
+// how long until the current batch should be posted, or 0 if it is
+// empty. Once the client is closed, the current batch is posted
+// straight away, and done is returned when there is nothing left.
+func (client *Client) next() (_ *batch, wait time.Duration, done bool) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	if len(client.queue) == 0 && client.current.count > 0 {
+		age := client.clock.Now().Sub(client.current.started)
+		if age < client.cfg.FlushInterval && !client.closed {
+			return nil, client.cfg.FlushInterval - age, false
+		}
+		client.queueCurrent()
+	}
+	if len(client.queue) == 0 {
+		return nil, 0, client.closed
+	}
+	b := client.queue[0]
+	client.queue = client.queue[1:]
+	return b, 0, false
+}
+
+// finish records that the batch was posted or, if err is not nil,
+// dropped.
+func (client *Client) finish(b *batch, err error) {
+	client.mu.Lock()
+	client.finished = b.seq
+	if err != nil {
+		client.lastErr = err
+		client.lastErrSeq = b.seq
+	}
+	close(client.changed)
+	client.changed = make(chan struct{})
+	client.mu.Unlock()
+
+	if err != nil && client.cfg.ErrorHandler != nil {
+		client.cfg.ErrorHandler(err)
+	}
+}
+
+// post posts the batch, retrying as configured.
+func (client *Client) post(b *batch) error {
+	body := b.body
+	if client.cfg.Gzip {
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		w.Write(body)
+		if err := w.Close(); err != nil {
+			return errors.Annotate(err, "compressing batch")
+		}
+		body = buf.Bytes()
+	}
+
+	interval := client.cfg.RetryInterval
+	for attempt := 0; ; attempt++ {
+		err := client.postOnce(body)
+		if err == nil {
+			return nil
+		}
+		statusErr, isStatus := err.(*statusError)
+		if (isStatus && !statusErr.retryable()) || attempt == client.cfg.MaxRetries {
+			return errors.Annotatef(err, "posting %d messages", b.count)
+		}
+
+		wait := interval
+		if isStatus && statusErr.retryAfter >= 0 {
+			wait = statusErr.retryAfter
+		}
+		if wait > client.cfg.MaxRetryInterval {
+			wait = client.cfg.MaxRetryInterval
+		}
+		if interval *= 2; interval > client.cfg.MaxRetryInterval {
+			interval = client.cfg.MaxRetryInterval
+		}
+		if !client.waitToRetry(wait) {
+			return errors.Annotatef(err, "posting %d messages", b.count)
+		}
+	}
+}
+
+// waitToRetry waits for the duration. It returns false if the client
+// was stopped first.
+func (client *Client) waitToRetry(wait time.Duration) bool {
+	timer := client.clock.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-client.stop:
+		return false
+	case <-timer.Chan():
+		return true
+	}
+}
+
+// postOnce makes a single request. A response other than 2xx results
+// in a *statusError.
+func (client *Client) postOnce(body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, client.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for name, values := range client.cfg.Header {
+		req.Header[name] = values
+	}
+	req.Header.Set("Content-Type", client.cfg.ContentType)
+	if client.cfg.Gzip {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+	switch {
+	case client.cfg.BearerToken != "":
+		req.Header.Set("Authorization", "Bearer "+client.cfg.BearerToken)
+	case client.cfg.Username != "" || client.cfg.Password != "":
+		req.SetBasicAuth(client.cfg.Username, client.cfg.Password)
+	}
+
+	resp, err := client.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	return &statusError{
+		code:       resp.StatusCode,
+		status:     resp.Status,
+		retryAfter: client.retryAfter(resp.Header.Get("Retry-After")),
+	}
+}
+
+// retryAfter returns the wait requested by a Retry-After header, which
+// holds either a number of seconds or a date, or -1 if there is none.
+func (client *Client) retryAfter(value string) time.Duration {
+	if value == "" {
+		return -1
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if wait := t.Sub(client.clock.Now()); wait > 0 {
+			return wait
+		}
+		return 0
+	}
+	return -1
+}
+
+// statusError is returned for an unsuccessful response.
+type statusError struct {
+	code   int
+	status string
+
+	// retryAfter is the wait requested by the response, or -1.
+	retryAfter time.Duration
+}
+
+func (err *statusError) Error() string {
+	return fmt.Sprintf("unexpected response %q", err.status)
+}
+
+// retryable reports whether the request may succeed if it is made
+// again.
+func (err *statusError) retryable() bool {
+	return err.code == http.StatusTooManyRequests || err.code >= 500
+}
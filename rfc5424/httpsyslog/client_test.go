@@ -0,0 +1,455 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package httpsyslog_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/rfc/v2/rfc5424"
+	"github.com/juju/rfc/v2/rfc5424/httpsyslog"
+)
+
+const longWait = 10 * time.Second
+
+// request is a request received by the test server.
+type request struct {
+	header   http.Header
+	messages []string
+}
+
+// response is a response for the test server to make.
+type response struct {
+	code       int
+	retryAfter string
+}
+
+type ClientSuite struct {
+	testing.IsolationSuite
+
+	server *httptest.Server
+
+	mu        sync.Mutex
+	responses []response
+	requests  chan request
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.responses = nil
+	s.requests = make(chan request, 100)
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+}
+
+func (s *ClientSuite) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	var body io.Reader = req.Body
+	if req.Header.Get("Content-Encoding") == "gzip" {
+		zr, err := gzip.NewReader(req.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		body = zr
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	reader := rfc5424.NewFrameReader(bytes.NewReader(data), 0)
+	received := request{header: req.Header}
+	for {
+		frame, framing, err := reader.ReadFrame()
+		if err == io.EOF {
+			break
+		}
+		if err != nil || framing != rfc5424.FramingOctetCounting {
+			http.Error(w, "bad framing", http.StatusBadRequest)
+			return
+		}
+		received.messages = append(received.messages, string(frame))
+	}
+	s.requests <- received
+
+	s.mu.Lock()
+	rsp := response{code: http.StatusNoContent}
+	if len(s.responses) > 0 {
+		rsp, s.responses = s.responses[0], s.responses[1:]
+	}
+	s.mu.Unlock()
+	if rsp.retryAfter != "" {
+		w.Header().Set("Retry-After", rsp.retryAfter)
+	}
+	w.WriteHeader(rsp.code)
+}
+
+func (s *ClientSuite) respond(responses ...response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.responses = append(s.responses, responses...)
+}
+
+func (s *ClientSuite) config() httpsyslog.Config {
+	return httpsyslog.Config{
+		URL: s.server.URL,
+	}
+}
+
+func (s *ClientSuite) newClient(c *gc.C, cfg httpsyslog.Config) *httpsyslog.Client {
+	client, err := httpsyslog.New(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { client.Close() })
+	return client
+}
+
+func (s *ClientSuite) send(c *gc.C, client *httpsyslog.Client, texts ...string) {
+	for _, text := range texts {
+		err := client.Send(newMessage(text))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *ClientSuite) next(c *gc.C) request {
+	select {
+	case req := <-s.requests:
+		return req
+	case <-time.After(longWait):
+		c.Fatalf("timed out waiting for request")
+	}
+	panic("unreachable")
+}
+
+func (s *ClientSuite) noRequest(c *gc.C) {
+	select {
+	case req := <-s.requests:
+		c.Fatalf("unexpected request: %v", req.messages)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func newMessage(text string) rfc5424.Message {
+	return rfc5424.Message{
+		Header: rfc5424.Header{
+			Priority: rfc5424.Priority{
+				Severity: rfc5424.SeverityWarning,
+				Facility: rfc5424.FacilityDaemon,
+			},
+			AppName: "an-app",
+		},
+		Msg: text,
+	}
+}
+
+func messages(texts ...string) []string {
+	var msgs []string
+	for _, text := range texts {
+		msgs = append(msgs, newMessage(text).String())
+	}
+	return msgs
+}
+
+func (s *ClientSuite) TestValidate(c *gc.C) {
+	tests := []struct {
+		change func(*httpsyslog.Config)
+		err    string
+	}{{
+		change: func(cfg *httpsyslog.Config) { cfg.URL = "" },
+		err:    "empty URL not valid",
+	}, {
+		change: func(cfg *httpsyslog.Config) { cfg.URL = "tcp://collector:514" },
+		err:    `URL scheme "tcp" not valid`,
+	}, {
+		change: func(cfg *httpsyslog.Config) { cfg.BearerToken, cfg.Username = "token", "user" },
+		err:    "BearerToken with Username or Password not valid",
+	}, {
+		change: func(cfg *httpsyslog.Config) { cfg.MaxBatchMessages = -1 },
+		err:    "negative MaxBatchMessages not valid",
+	}, {
+		change: func(cfg *httpsyslog.Config) { cfg.MaxRetries = -2 },
+		err:    "MaxRetries -2 not valid",
+	}}
+	for i, test := range tests {
+		c.Logf("test %d: %s", i, test.err)
+		cfg := s.config()
+		test.change(&cfg)
+		err := cfg.Validate()
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+		_, err = httpsyslog.New(cfg)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ClientSuite) TestBatches(c *gc.C) {
+	cfg := s.config()
+	cfg.MaxBatchMessages = 2
+	client := s.newClient(c, cfg)
+
+	s.send(c, client, "one", "two", "three", "four", "five")
+	c.Assert(client.Flush(), jc.ErrorIsNil)
+
+	req := s.next(c)
+	c.Check(req.header.Get("Content-Type"), gc.Equals, httpsyslog.DefaultContentType)
+	c.Check(req.header.Get("Content-Encoding"), gc.Equals, "")
+	c.Check(req.header.Get("Authorization"), gc.Equals, "")
+	c.Check(req.messages, jc.DeepEquals, messages("one", "two"))
+	c.Check(s.next(c).messages, jc.DeepEquals, messages("three", "four"))
+	c.Check(s.next(c).messages, jc.DeepEquals, messages("five"))
+	s.noRequest(c)
+}
+
+func (s *ClientSuite) TestSendRaw(c *gc.C) {
+	cfg := s.config()
+	cfg.Defaults.StructuredData = rfc5424.StructuredData{
+		rfc5424.GenericStructuredDataElement{SDID: "origin"},
+	}
+	client := s.newClient(c, cfg)
+	raw, err := rfc5424.ParseRawMessage(`<28>1 1970-01-01T16:05:21+01:00 - - - - [spam x="\y"] a message`)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = client.SendRaw(raw)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(client.Flush(), jc.ErrorIsNil)
+
+	c.Check(s.next(c).messages, jc.DeepEquals, []string{
+		`<28>1 1970-01-01T16:05:21+01:00 - - - - [spam x="\y"][origin] a message`,
+	})
+}
+
+func (s *ClientSuite) TestMaxBatchBytes(c *gc.C) {
+	cfg := s.config()
+	// Each framed message is about 35 bytes.
+	cfg.MaxBatchBytes = 50
+	client := s.newClient(c, cfg)
+
+	s.send(c, client, "one", "two")
+	c.Assert(client.Close(), jc.ErrorIsNil)
+	c.Check(s.next(c).messages, jc.DeepEquals, messages("one"))
+	c.Check(s.next(c).messages, jc.DeepEquals, messages("two"))
+}
+
+func (s *ClientSuite) TestFlushInterval(c *gc.C) {
+	clock := testclock.NewClock(time.Now())
+	cfg := s.config()
+	cfg.FlushInterval = 5 * time.Second
+	cfg.Clock = clock
+	client := s.newClient(c, cfg)
+
+	s.send(c, client, "one")
+	s.noRequest(c)
+	err := clock.WaitAdvance(5*time.Second, longWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.next(c).messages, jc.DeepEquals, messages("one"))
+}
+
+func (s *ClientSuite) TestGzipAndBearerToken(c *gc.C) {
+	cfg := s.config()
+	cfg.Gzip = true
+	cfg.BearerToken = "s3cret"
+	cfg.ContentType = "application/syslog"
+	cfg.Header = http.Header{"X-Source": {"tests"}}
+	client := s.newClient(c, cfg)
+
+	s.send(c, client, "one", "two")
+	c.Assert(client.Flush(), jc.ErrorIsNil)
+
+	req := s.next(c)
+	c.Check(req.header.Get("Content-Encoding"), gc.Equals, "gzip")
+	c.Check(req.header.Get("Content-Type"), gc.Equals, "application/syslog")
+	c.Check(req.header.Get("Authorization"), gc.Equals, "Bearer s3cret")
+	c.Check(req.header.Get("X-Source"), gc.Equals, "tests")
+	c.Check(req.messages, jc.DeepEquals, messages("one", "two"))
+}
+
+func (s *ClientSuite) TestBasicAuth(c *gc.C) {
+	cfg := s.config()
+	cfg.Username = "user"
+	cfg.Password = "pass"
+	client := s.newClient(c, cfg)
+
+	s.send(c, client, "one")
+	c.Assert(client.Flush(), jc.ErrorIsNil)
+	req := &http.Request{Header: s.next(c).header}
+	username, password, ok := req.BasicAuth()
+	c.Check(ok, jc.IsTrue)
+	c.Check(username, gc.Equals, "user")
+	c.Check(password, gc.Equals, "pass")
+}
+
+func (s *ClientSuite) TestRetry(c *gc.C) {
+	s.respond(
+		response{code: http.StatusServiceUnavailable},
+		response{code: http.StatusTooManyRequests, retryAfter: "7"},
+	)
+	clock := testclock.NewClock(time.Now())
+	cfg := s.config()
+	cfg.RetryInterval = time.Second
+	cfg.Clock = clock
+	client := s.newClient(c, cfg)
+
+	s.send(c, client, "one")
+	flushed := make(chan error, 1)
+	go func() {
+		flushed <- client.Flush()
+	}()
+
+	c.Check(s.next(c).messages, jc.DeepEquals, messages("one"))
+	// Without Retry-After, the retry interval is used.
+	err := clock.WaitAdvance(time.Second, longWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.next(c).messages, jc.DeepEquals, messages("one"))
+	// Retry-After is honoured.
+	err = clock.WaitAdvance(6*time.Second, longWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.noRequest(c)
+	clock.Advance(time.Second)
+	c.Check(s.next(c).messages, jc.DeepEquals, messages("one"))
+
+	select {
+	case err := <-flushed:
+		c.Check(err, jc.ErrorIsNil)
+	case <-time.After(longWait):
+		c.Fatalf("timed out waiting for flush")
+	}
+}
+
+func (s *ClientSuite) TestRetryAfterDate(c *gc.C) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	s.respond(response{
+		code:       http.StatusServiceUnavailable,
+		retryAfter: now.Add(30 * time.Second).Format(http.TimeFormat),
+	})
+	clock := testclock.NewClock(now)
+	cfg := s.config()
+	cfg.Clock = clock
+	client := s.newClient(c, cfg)
+
+	s.send(c, client, "one")
+	go client.Flush()
+	s.next(c)
+	err := clock.WaitAdvance(29*time.Second, longWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.noRequest(c)
+	clock.Advance(time.Second)
+	c.Check(s.next(c).messages, jc.DeepEquals, messages("one"))
+}
+
+func (s *ClientSuite) TestRetryAfterCapped(c *gc.C) {
+	s.respond(response{code: http.StatusServiceUnavailable, retryAfter: "86400"})
+	clock := testclock.NewClock(time.Now())
+	cfg := s.config()
+	cfg.MaxRetryInterval = 10 * time.Second
+	cfg.Clock = clock
+	client := s.newClient(c, cfg)
+
+	s.send(c, client, "one")
+	go client.Flush()
+	s.next(c)
+	err := clock.WaitAdvance(10*time.Second, longWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.next(c).messages, jc.DeepEquals, messages("one"))
+}
+
+func (s *ClientSuite) TestCloseStopsRetries(c *gc.C) {
+	s.respond(
+		response{code: http.StatusServiceUnavailable},
+		response{code: http.StatusServiceUnavailable},
+	)
+	cfg := s.config()
+	cfg.RetryInterval = time.Hour
+	client := s.newClient(c, cfg)
+
+	s.send(c, client, "one")
+	go client.Flush()
+	s.next(c)
+	s.send(c, client, "two")
+
+	// The retry of the first batch is abandoned, but the second batch
+	// is still posted once.
+	closed := make(chan error, 1)
+	go func() {
+		closed <- client.Close()
+	}()
+	select {
+	case err := <-closed:
+		c.Check(err, gc.ErrorMatches, `posting 1 messages: unexpected response "503 Service Unavailable"`)
+	case <-time.After(longWait):
+		c.Fatalf("timed out waiting for close")
+	}
+	c.Check(s.next(c).messages, jc.DeepEquals, messages("two"))
+	s.noRequest(c)
+}
+
+func (s *ClientSuite) TestNotRetried(c *gc.C) {
+	s.respond(response{code: http.StatusBadRequest})
+	errs := make(chan error, 1)
+	cfg := s.config()
+	cfg.ErrorHandler = func(err error) {
+		errs <- err
+	}
+	client := s.newClient(c, cfg)
+
+	s.send(c, client, "one")
+	err := client.Flush()
+	c.Check(err, gc.ErrorMatches, `posting 1 messages: unexpected response "400 Bad Request"`)
+	c.Check(<-errs, gc.ErrorMatches, err.Error())
+	s.next(c)
+	s.noRequest(c)
+
+	// Later batches are unaffected.
+	s.send(c, client, "two")
+	c.Assert(client.Flush(), jc.ErrorIsNil)
+	c.Check(s.next(c).messages, jc.DeepEquals, messages("two"))
+}
+
+func (s *ClientSuite) TestRetriesExhausted(c *gc.C) {
+	for i := 0; i < 3; i++ {
+		s.respond(response{code: http.StatusInternalServerError, retryAfter: "0"})
+	}
+	cfg := s.config()
+	cfg.MaxRetries = 2
+	client := s.newClient(c, cfg)
+
+	s.send(c, client, "one")
+	err := client.Flush()
+	c.Check(err, gc.ErrorMatches, `posting 1 messages: unexpected response "500 Internal Server Error"`)
+	for i := 0; i < 3; i++ {
+		s.next(c)
+	}
+	s.noRequest(c)
+}
+
+func (s *ClientSuite) TestNoRetries(c *gc.C) {
+	s.respond(response{code: http.StatusInternalServerError, retryAfter: "0"})
+	cfg := s.config()
+	cfg.MaxRetries = -1
+	client := s.newClient(c, cfg)
+
+	s.send(c, client, "one")
+	err := client.Flush()
+	c.Check(err, gc.ErrorMatches, `posting 1 messages: unexpected response "500 Internal Server Error"`)
+	s.next(c)
+	s.noRequest(c)
+}
+
+func (s *ClientSuite) TestSendAfterClose(c *gc.C) {
+	client := s.newClient(c, s.config())
+	c.Assert(client.Close(), jc.ErrorIsNil)
+	err := client.Send(newMessage("late"))
+	c.Check(err, gc.ErrorMatches, "HTTP client closed")
+}
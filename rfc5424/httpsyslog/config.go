@@ -0,0 +1,210 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package httpsyslog
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+
+	"github.com/juju/rfc/v2/rfc5424"
+)
+
+const (
+	// DefaultContentType is the content type used when
+	// Config.ContentType is not set.
+	DefaultContentType = "application/octet-stream"
+
+	// DefaultMaxBatchMessages is the batch size used when
+	// Config.MaxBatchMessages is not set.
+	DefaultMaxBatchMessages = 500
+
+	// DefaultMaxBatchBytes is the batch size used when
+	// Config.MaxBatchBytes is not set.
+	DefaultMaxBatchBytes = 1 << 20
+
+	// DefaultFlushInterval is the flush interval used when
+	// Config.FlushInterval is not set.
+	DefaultFlushInterval = time.Second
+
+	// DefaultMaxQueuedBatches is the queue length used when
+	// Config.MaxQueuedBatches is not set.
+	DefaultMaxQueuedBatches = 16
+
+	// DefaultMaxRetries is the number of retries used when
+	// Config.MaxRetries is not set.
+	DefaultMaxRetries = 5
+
+	// DefaultRetryInterval is the retry interval used when
+	// Config.RetryInterval is not set.
+	DefaultRetryInterval = time.Second
+
+	// DefaultMaxRetryInterval is the maximum retry interval used when
+	// Config.MaxRetryInterval is not set.
+	DefaultMaxRetryInterval = time.Minute
+
+	// DefaultRequestTimeout limits each request when Config.HTTPClient
+	// is not set.
+	DefaultRequestTimeout = 30 * time.Second
+)
+
+// Config is the configuration for a Client.
+type Config struct {
+	// ClientConfig holds the settings shared with rfc5424.Client.
+	// Framing is ignored, as messages are always octet-counted, and
+	// SendTimeout limits how long Send waits when the queue is full.
+	rfc5424.ClientConfig
+
+	// URL is where batches of messages are posted. It must use the
+	// http or https scheme.
+	URL string
+
+	// HTTPClient is used to post batches. Its Timeout limits each
+	// attempt. If it is nil then a client like http.DefaultClient but
+	// with a Timeout of DefaultRequestTimeout is used.
+	HTTPClient *http.Client
+
+	// ContentType is the Content-Type of each request. If not set,
+	// DefaultContentType is used.
+	ContentType string
+
+	// Header holds extra headers for each request.
+	Header http.Header
+
+	// BearerToken, if set, is sent in the Authorization header.
+	BearerToken string
+
+	// Username and Password, if set, are sent in the Authorization
+	// header using basic authentication. They may not be used with
+	// BearerToken.
+	Username string
+	Password string
+
+	// Gzip compresses the body of each request.
+	Gzip bool
+
+	// MaxBatchMessages is the largest number of messages posted in
+	// one request. If not set, DefaultMaxBatchMessages is used.
+	MaxBatchMessages int
+
+	// MaxBatchBytes is the largest size of the body of a request,
+	// before compression. A message that is larger on its own is
+	// posted alone. If not set, DefaultMaxBatchBytes is used.
+	MaxBatchBytes int
+
+	// FlushInterval is the longest that a message waits for its batch
+	// to fill before the batch is posted. If not set,
+	// DefaultFlushInterval is used.
+	FlushInterval time.Duration
+
+	// MaxQueuedBatches is the number of full batches that may wait to
+	// be posted. Send blocks while the queue is full. If not set,
+	// DefaultMaxQueuedBatches is used.
+	MaxQueuedBatches int
+
+	// MaxRetries is the number of times a batch is posted again after
+	// a network error or a 429 or 5xx response, before it is dropped.
+	// If not set, DefaultMaxRetries is used; set it to -1 to post each
+	// batch only once.
+	MaxRetries int
+
+	// RetryInterval is how long to wait before the first retry. It
+	// doubles with each retry up to MaxRetryInterval, unless the
+	// response has a Retry-After header, which is honoured instead up
+	// to MaxRetryInterval. If not set, DefaultRetryInterval is used.
+	RetryInterval time.Duration
+
+	// MaxRetryInterval is the longest wait between retries, including
+	// any requested by Retry-After. If not set, DefaultMaxRetryInterval
+	// is used.
+	MaxRetryInterval time.Duration
+
+	// ErrorHandler, if set, is called with the error for each batch
+	// that could not be posted and so was dropped.
+	ErrorHandler func(error)
+
+	// Clock is used to time flushes and retries. If it is nil then
+	// the wall clock is used.
+	Clock clock.Clock
+}
+
+// Validate ensures that the config is correct.
+func (cfg Config) Validate() error {
+	if cfg.URL == "" {
+		return errors.NotValidf("empty URL")
+	}
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return errors.NotValidf("URL %q", cfg.URL)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.NotValidf("URL scheme %q", u.Scheme)
+	}
+	if cfg.BearerToken != "" && (cfg.Username != "" || cfg.Password != "") {
+		return errors.NotValidf("BearerToken with Username or Password")
+	}
+	if cfg.MaxBatchMessages < 0 {
+		return errors.NotValidf("negative MaxBatchMessages")
+	}
+	if cfg.MaxBatchBytes < 0 {
+		return errors.NotValidf("negative MaxBatchBytes")
+	}
+	if cfg.FlushInterval < 0 {
+		return errors.NotValidf("negative FlushInterval")
+	}
+	if cfg.MaxQueuedBatches < 0 {
+		return errors.NotValidf("negative MaxQueuedBatches")
+	}
+	if cfg.MaxRetries < -1 {
+		return errors.NotValidf("MaxRetries %d", cfg.MaxRetries)
+	}
+	if cfg.RetryInterval < 0 {
+		return errors.NotValidf("negative RetryInterval")
+	}
+	if cfg.MaxRetryInterval < 0 {
+		return errors.NotValidf("negative MaxRetryInterval")
+	}
+	return nil
+}
+
+// withDefaults returns the config with any unset values defaulted.
+func (cfg Config) withDefaults() Config {
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: DefaultRequestTimeout}
+	}
+	if cfg.ContentType == "" {
+		cfg.ContentType = DefaultContentType
+	}
+	if cfg.MaxBatchMessages == 0 {
+		cfg.MaxBatchMessages = DefaultMaxBatchMessages
+	}
+	if cfg.MaxBatchBytes == 0 {
+		cfg.MaxBatchBytes = DefaultMaxBatchBytes
+	}
+	if cfg.FlushInterval == 0 {
+		cfg.FlushInterval = DefaultFlushInterval
+	}
+	if cfg.MaxQueuedBatches == 0 {
+		cfg.MaxQueuedBatches = DefaultMaxQueuedBatches
+	}
+	switch cfg.MaxRetries {
+	case 0:
+		cfg.MaxRetries = DefaultMaxRetries
+	case -1:
+		cfg.MaxRetries = 0
+	}
+	if cfg.RetryInterval == 0 {
+		cfg.RetryInterval = DefaultRetryInterval
+	}
+	if cfg.MaxRetryInterval == 0 {
+		cfg.MaxRetryInterval = DefaultMaxRetryInterval
+	}
+	if cfg.Clock == nil {
+		cfg.Clock = clock.WallClock
+	}
+	return cfg
+}
@@ -0,0 +1,26 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+// The httpsyslog package sends syslog messages to an HTTP endpoint,
+// as accepted by several hosted log services. Messages are batched,
+// and each batch is posted as the body of one request, holding the
+// messages octet-counted as for RFC 6587, i.e. each prefixed with its
+// length in octets and a space.
+//
+// Batches that fail with a network error or with a 429 or 5xx response
+// are posted again, honouring any Retry-After header up to the maximum
+// retry interval. Other responses are not retried. The body may be compressed with gzip, and requests
+// may carry a bearer token or basic authentication.
+//
+// A Client is an rfc5424.Sender, so it may be used anywhere a syslog
+// client is, e.g. as a router destination:
+//
+//	client, err := httpsyslog.New(httpsyslog.Config{
+//		URL:         "https://logs.example.com/ingest",
+//		BearerToken: token,
+//		Gzip:        true,
+//	})
+//	...
+//	defer client.Close()
+//	err = client.Send(msg)
+package httpsyslog
@@ -0,0 +1,282 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package rfc5424
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// The JSON form of a Message is an object modelled on rsyslog's
+// RFC 5424 JSON template:
+//
+//	{
+//	  "facility": "daemon",
+//	  "severity": "warning",
+//	  "timestamp": "2003-10-11T22:14:15.003Z",
+//	  "hostname": "mymachine.example.com",
+//	  "app-name": "evntslog",
+//	  "procid": "1234",
+//	  "msgid": "ID47",
+//	  "structured-data": [
+//	    {"id": "exampleSDID@32473", "params": [{"name": "iut", "value": "3"}]}
+//	  ],
+//	  "msg": "An application event log entry..."
+//	}
+//
+// Empty fields other than the priority are left out. The facility and
+// severity are given by their MarshalText names; when decoding, any
+// name accepted by ParseSeverity or ParseFacility, or a numeric code,
+// is accepted too. A Header is encoded the same way, without the last
+// two fields, and a Priority as just its first two. The hostname is
+// encoded as its RFC 5424 string, so decoding it has the same
+// limitations as ParseHostname. Decoded structured data elements are
+// always GenericStructuredDataElement values.
+
+// unmarshalCode decodes a JSON number, returning false if the data is
+// not a number.
+func unmarshalCode(data []byte) (int, bool) {
+	var code int
+	if err := json.Unmarshal(data, &code); err != nil {
+		return 0, false
+	}
+	return code, true
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Either a name accepted by
+// ParseSeverity or a numeric code may be given.
+func (s *Severity) UnmarshalJSON(data []byte) error {
+	sev, err := unmarshalSeverity(data)
+	if err != nil {
+		return fmt.Errorf("bad Severity: %v", err)
+	}
+	*s = sev
+	return nil
+}
+
+func unmarshalSeverity(data []byte) (Severity, error) {
+	if code, ok := unmarshalCode(data); ok {
+		sev := decodeSeverity(code)
+		return sev, sev.Validate()
+	}
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return 0, fmt.Errorf("expected name or number, got %s", data)
+	}
+	return ParseSeverity(name)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Either a name accepted by
+// ParseFacility or a numeric code may be given.
+func (f *Facility) UnmarshalJSON(data []byte) error {
+	fac, err := unmarshalFacility(data)
+	if err != nil {
+		return fmt.Errorf("bad Facility: %v", err)
+	}
+	*f = fac
+	return nil
+}
+
+func unmarshalFacility(data []byte) (Facility, error) {
+	if code, ok := unmarshalCode(data); ok {
+		fac := decodeFacility(code)
+		if code < 0 || fac.Validate() != nil {
+			return 0, fmt.Errorf("facility code %d not recognized", code)
+		}
+		return fac, nil
+	}
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return 0, fmt.Errorf("expected name or number, got %s", data)
+	}
+	return ParseFacility(name)
+}
+
+type jsonPriority struct {
+	Facility Facility `json:"facility"`
+	Severity Severity `json:"severity"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p Priority) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonPriority{
+		Facility: p.Facility,
+		Severity: p.Severity,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *Priority) UnmarshalJSON(data []byte) error {
+	var jp jsonPriority
+	if err := json.Unmarshal(data, &jp); err != nil {
+		return err
+	}
+	p.Facility = jp.Facility
+	p.Severity = jp.Severity
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (h Hostname) MarshalJSON() ([]byte, error) {
+	str := h.String()
+	if str == "-" {
+		str = ""
+	}
+	return json.Marshal(str)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (h *Hostname) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	*h = ParseHostname(str)
+	return nil
+}
+
+type jsonHeader struct {
+	Facility  Facility `json:"facility"`
+	Severity  Severity `json:"severity"`
+	Timestamp string   `json:"timestamp,omitempty"`
+	Hostname  string   `json:"hostname,omitempty"`
+	AppName   AppName  `json:"app-name,omitempty"`
+	ProcID    ProcID   `json:"procid,omitempty"`
+	MsgID     MsgID    `json:"msgid,omitempty"`
+}
+
+func newJSONHeader(h Header) jsonHeader {
+	jh := jsonHeader{
+		Facility: h.Facility,
+		Severity: h.Severity,
+		AppName:  h.AppName,
+		ProcID:   h.ProcID,
+		MsgID:    h.MsgID,
+	}
+	if hostname := h.Hostname.String(); hostname != "-" {
+		jh.Hostname = hostname
+	}
+	if !h.Timestamp.IsZero() {
+		jh.Timestamp = h.Timestamp.String()
+	}
+	return jh
+}
+
+func (jh jsonHeader) header() (Header, error) {
+	h := Header{
+		Priority: Priority{
+			Facility: jh.Facility,
+			Severity: jh.Severity,
+		},
+		Hostname: ParseHostname(jh.Hostname),
+		AppName:  jh.AppName,
+		ProcID:   jh.ProcID,
+		MsgID:    jh.MsgID,
+	}
+	if jh.Timestamp != "" {
+		ts, err := ParseTimestamp(jh.Timestamp)
+		if err != nil {
+			return h, fmt.Errorf("bad Timestamp: %v", err)
+		}
+		h.Timestamp = ts
+	}
+	return h, nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (h Header) MarshalJSON() ([]byte, error) {
+	return json.Marshal(newJSONHeader(h))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (h *Header) UnmarshalJSON(data []byte) error {
+	var jh jsonHeader
+	if err := json.Unmarshal(data, &jh); err != nil {
+		return err
+	}
+	header, err := jh.header()
+	if err != nil {
+		return err
+	}
+	*h = header
+	return nil
+}
+
+type jsonStructuredDataParam struct {
+	Name  StructuredDataName       `json:"name"`
+	Value StructuredDataParamValue `json:"value"`
+}
+
+type jsonStructuredDataElement struct {
+	ID     StructuredDataName        `json:"id"`
+	Params []jsonStructuredDataParam `json:"params,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (sd StructuredData) MarshalJSON() ([]byte, error) {
+	elements := make([]jsonStructuredDataElement, len(sd))
+	for i, sde := range sd {
+		elements[i].ID = sde.ID()
+		for _, param := range sde.Params() {
+			elements[i].Params = append(elements[i].Params, jsonStructuredDataParam(param))
+		}
+	}
+	return json.Marshal(elements)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (sd *StructuredData) UnmarshalJSON(data []byte) error {
+	var elements []jsonStructuredDataElement
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+	var result StructuredData
+	for _, element := range elements {
+		sde := GenericStructuredDataElement{SDID: element.ID}
+		for _, param := range element.Params {
+			sde.Data = append(sde.Data, StructuredDataParam(param))
+		}
+		result = append(result, sde)
+	}
+	*sd = result
+	return nil
+}
+
+type jsonMessage struct {
+	jsonHeader
+	StructuredData StructuredData `json:"structured-data,omitempty"`
+	Msg            string         `json:"msg,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (m Message) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonMessage{
+		jsonHeader:     newJSONHeader(m.Header),
+		StructuredData: m.StructuredData,
+		Msg:            m.Msg,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The decoded message is
+// validated.
+func (m *Message) UnmarshalJSON(data []byte) error {
+	var jm jsonMessage
+	if err := json.Unmarshal(data, &jm); err != nil {
+		return err
+	}
+	header, err := jm.header()
+	if err != nil {
+		return fmt.Errorf("bad Header: %v", err)
+	}
+	msg := Message{
+		Header:         header,
+		StructuredData: jm.StructuredData,
+		Msg:            jm.Msg,
+	}
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+	*m = msg
+	return nil
+}
@@ -0,0 +1,222 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package rfc5424_test
+
+import (
+	"encoding/json"
+	"net"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/rfc/v2/rfc5424"
+)
+
+type JSONSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&JSONSuite{})
+
+const messageJSON = `{` +
+	`"facility":"daemon",` +
+	`"severity":"warning",` +
+	`"timestamp":"1970-01-01T15:05:21.000000123Z",` +
+	`"hostname":"a.b.org",` +
+	`"app-name":"an-app",` +
+	`"procid":"119",` +
+	`"msgid":"xyz...",` +
+	`"structured-data":[{"id":"spam","params":[{"name":"x","value":"y"}]},{"id":"eggs"}],` +
+	`"msg":"a message"` +
+	`}`
+
+func (s *JSONSuite) TestMarshalMessage(c *gc.C) {
+	msg := rfc5424.Message{
+		Header: rfc5424.Header{
+			Priority: rfc5424.Priority{
+				Severity: rfc5424.SeverityWarning,
+				Facility: rfc5424.FacilityDaemon,
+			},
+			Timestamp: rfc5424.Timestamp{time.Unix(54321, 123).UTC()},
+			Hostname:  rfc5424.Hostname{FQDN: "a.b.org"},
+			AppName:   "an-app",
+			ProcID:    "119",
+			MsgID:     "xyz...",
+		},
+		StructuredData: rfc5424.StructuredData{
+			newStubElement(&testing.Stub{}, "spam", "x=y"),
+			newStubElement(&testing.Stub{}, "eggs"),
+		},
+		Msg: "a message",
+	}
+
+	data, err := json.Marshal(msg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(string(data), gc.Equals, messageJSON)
+}
+
+func (s *JSONSuite) TestMarshalMessageMinimal(c *gc.C) {
+	data, err := json.Marshal(rfc5424.Message{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(string(data), gc.Equals, `{"facility":"user","severity":"emerg"}`)
+}
+
+func (s *JSONSuite) TestUnmarshalMessage(c *gc.C) {
+	var msg rfc5424.Message
+	err := json.Unmarshal([]byte(messageJSON), &msg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(msg, jc.DeepEquals, rfc5424.Message{
+		Header: rfc5424.Header{
+			Priority: rfc5424.Priority{
+				Severity: rfc5424.SeverityWarning,
+				Facility: rfc5424.FacilityDaemon,
+			},
+			Timestamp: rfc5424.Timestamp{time.Unix(54321, 123).UTC()},
+			Hostname:  rfc5424.Hostname{FQDN: "a.b.org"},
+			AppName:   "an-app",
+			ProcID:    "119",
+			MsgID:     "xyz...",
+		},
+		StructuredData: rfc5424.StructuredData{
+			rfc5424.GenericStructuredDataElement{
+				SDID: "spam",
+				Data: []rfc5424.StructuredDataParam{{
+					Name:  "x",
+					Value: "y",
+				}},
+			},
+			rfc5424.GenericStructuredDataElement{
+				SDID: "eggs",
+			},
+		},
+		Msg: "a message",
+	})
+}
+
+func (s *JSONSuite) TestUnmarshalMessageInvalid(c *gc.C) {
+	var msg rfc5424.Message
+	err := json.Unmarshal([]byte(`{"app-name":"an app"}`), &msg)
+
+	c.Check(err, gc.ErrorMatches, `bad Header: bad AppName: must be printable US ASCII \(\\x20 at pos 2\)`)
+}
+
+func (s *JSONSuite) TestUnmarshalMessageBadTimestamp(c *gc.C) {
+	var msg rfc5424.Message
+	err := json.Unmarshal([]byte(`{"timestamp":"yesterday"}`), &msg)
+
+	c.Check(err, gc.ErrorMatches, `bad Header: bad Timestamp: .*`)
+}
+
+func (s *JSONSuite) TestHeader(c *gc.C) {
+	header := rfc5424.Header{
+		Priority: rfc5424.Priority{
+			Severity: rfc5424.SeverityDebug,
+			Facility: rfc5424.FacilityLocal7,
+		},
+		Hostname: rfc5424.Hostname{StaticIP: net.ParseIP("10.0.0.1")},
+	}
+
+	data, err := json.Marshal(header)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, `{"facility":"local7","severity":"debug","hostname":"10.0.0.1"}`)
+
+	var decoded rfc5424.Header
+	err = json.Unmarshal(data, &decoded)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(decoded, jc.DeepEquals, header)
+}
+
+func (s *JSONSuite) TestPriority(c *gc.C) {
+	data, err := json.Marshal(rfc5424.Priority{
+		Severity: rfc5424.SeverityNotice,
+		Facility: rfc5424.FacilityMail,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, `{"facility":"mail","severity":"notice"}`)
+
+	var p rfc5424.Priority
+	err = json.Unmarshal([]byte(`{"facility":3,"severity":"NOTICE"}`), &p)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(p, jc.DeepEquals, rfc5424.Priority{
+		Severity: rfc5424.SeverityNotice,
+		Facility: rfc5424.FacilityDaemon,
+	})
+}
+
+func (s *JSONSuite) TestUnmarshalSeverity(c *gc.C) {
+	for _, test := range []struct {
+		data     string
+		expected rfc5424.Severity
+		err      string
+	}{{
+		data:     `"err"`,
+		expected: rfc5424.SeverityError,
+	}, {
+		data:     `7`,
+		expected: rfc5424.SeverityDebug,
+	}, {
+		data: `8`,
+		err:  `bad Severity: severity 8 not recognized`,
+	}, {
+		data: `"loud"`,
+		err:  `bad Severity: unknown severity "loud"`,
+	}, {
+		data: `true`,
+		err:  `bad Severity: expected name or number, got true`,
+	}} {
+		c.Logf("data %s", test.data)
+		var sev rfc5424.Severity
+		err := json.Unmarshal([]byte(test.data), &sev)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(sev, gc.Equals, test.expected)
+	}
+}
+
+func (s *JSONSuite) TestUnmarshalFacility(c *gc.C) {
+	for _, test := range []struct {
+		data     string
+		expected rfc5424.Facility
+		err      string
+	}{{
+		data:     `"local0"`,
+		expected: rfc5424.FacilityLocal0,
+	}, {
+		data:     `0`,
+		expected: rfc5424.FacilityKern,
+	}, {
+		data:     `12`,
+		expected: rfc5424.FacilityNTP,
+	}, {
+		data: `24`,
+		err:  `bad Facility: facility code 24 not recognized`,
+	}, {
+		data: `"nowhere"`,
+		err:  `bad Facility: unknown facility "nowhere"`,
+	}} {
+		c.Logf("data %s", test.data)
+		var fac rfc5424.Facility
+		err := json.Unmarshal([]byte(test.data), &fac)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(fac, gc.Equals, test.expected)
+	}
+}
+
+func (s *JSONSuite) TestMarshalBadSeverity(c *gc.C) {
+	_, err := json.Marshal(rfc5424.Severity(10))
+
+	c.Check(err, gc.ErrorMatches, `.*severity 10 not recognized`)
+}
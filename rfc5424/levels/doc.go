@@ -0,0 +1,15 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+// The levels package converts between RFC 5424 severities and the
+// levels used by other logging systems: OpenTelemetry, journald,
+// log/slog, loggo and log/syslog.
+//
+// Each conversion comes in a pair, e.g. FromSlog and ToSlog. Converting
+// a severity to OpenTelemetry, journald, log/slog or log/syslog and
+// back returns the original severity. loggo has fewer levels than
+// syslog, so NOTICE comes back as INFO, and ALERT and EMERGENCY come
+// back as CRIT. Converting in the other direction may lose information
+// where the other system has more levels than syslog; each function
+// documents where.
+package levels
@@ -0,0 +1,29 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package levels
+
+import (
+	"github.com/juju/rfc/v2/rfc5424"
+)
+
+// ToJournald returns the journald PRIORITY field for the severity.
+// journald uses the syslog severity codes, from 0 (emerg) to 7
+// (debug), so there is no loss.
+func ToJournald(sev rfc5424.Severity) int {
+	return int(sev - rfc5424.SeverityEmergency)
+}
+
+// FromJournald returns the severity for a journald PRIORITY field.
+// Values out of range are clamped, so negative values are EMERGENCY
+// and values above 7 are DEBUG.
+func FromJournald(priority int) rfc5424.Severity {
+	switch {
+	case priority < 0:
+		return rfc5424.SeverityEmergency
+	case priority > ToJournald(rfc5424.SeverityDebug):
+		return rfc5424.SeverityDebug
+	default:
+		return rfc5424.SeverityEmergency + rfc5424.Severity(priority)
+	}
+}
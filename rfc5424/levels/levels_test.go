@@ -0,0 +1,126 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package levels_test
+
+import (
+	"log/slog"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/rfc/v2/rfc5424"
+	"github.com/juju/rfc/v2/rfc5424/levels"
+)
+
+type LevelsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&LevelsSuite{})
+
+var allSeverities = []rfc5424.Severity{
+	rfc5424.SeverityEmergency,
+	rfc5424.SeverityAlert,
+	rfc5424.SeverityCrit,
+	rfc5424.SeverityError,
+	rfc5424.SeverityWarning,
+	rfc5424.SeverityNotice,
+	rfc5424.SeverityInformational,
+	rfc5424.SeverityDebug,
+}
+
+func (s *LevelsSuite) TestRoundTrip(c *gc.C) {
+	for _, sev := range allSeverities {
+		c.Logf("trying %s", sev)
+		c.Check(levels.FromOTel(levels.ToOTel(sev)), gc.Equals, sev)
+		c.Check(levels.FromJournald(levels.ToJournald(sev)), gc.Equals, sev)
+		c.Check(levels.FromSlog(levels.ToSlog(sev)), gc.Equals, sev)
+	}
+}
+
+func (s *LevelsSuite) TestRoundTripLoggo(c *gc.C) {
+	lossy := map[rfc5424.Severity]rfc5424.Severity{
+		rfc5424.SeverityEmergency: rfc5424.SeverityCrit,
+		rfc5424.SeverityAlert:     rfc5424.SeverityCrit,
+		rfc5424.SeverityNotice:    rfc5424.SeverityInformational,
+	}
+	for _, sev := range allSeverities {
+		c.Logf("trying %s", sev)
+		expected, ok := lossy[sev]
+		if !ok {
+			expected = sev
+		}
+		c.Check(levels.FromLoggo(levels.ToLoggo(sev)), gc.Equals, expected)
+	}
+}
+
+func (s *LevelsSuite) TestOTel(c *gc.C) {
+	for number, expected := range map[int]rfc5424.Severity{
+		-1: rfc5424.SeverityInformational,
+		0:  rfc5424.SeverityInformational,
+		1:  rfc5424.SeverityDebug,
+		5:  rfc5424.SeverityDebug,
+		9:  rfc5424.SeverityInformational,
+		10: rfc5424.SeverityNotice,
+		12: rfc5424.SeverityNotice,
+		13: rfc5424.SeverityWarning,
+		16: rfc5424.SeverityWarning,
+		17: rfc5424.SeverityError,
+		18: rfc5424.SeverityCrit,
+		19: rfc5424.SeverityAlert,
+		20: rfc5424.SeverityAlert,
+		21: rfc5424.SeverityEmergency,
+		24: rfc5424.SeverityEmergency,
+		99: rfc5424.SeverityEmergency,
+	} {
+		c.Logf("trying %d", number)
+		c.Check(levels.FromOTel(number), gc.Equals, expected)
+	}
+	c.Check(levels.ToOTel(rfc5424.SeverityAlert), gc.Equals, 19)
+}
+
+func (s *LevelsSuite) TestJournald(c *gc.C) {
+	c.Check(levels.ToJournald(rfc5424.SeverityWarning), gc.Equals, 4)
+	c.Check(levels.FromJournald(3), gc.Equals, rfc5424.SeverityError)
+	c.Check(levels.FromJournald(-1), gc.Equals, rfc5424.SeverityEmergency)
+	c.Check(levels.FromJournald(8), gc.Equals, rfc5424.SeverityDebug)
+}
+
+func (s *LevelsSuite) TestSlog(c *gc.C) {
+	for level, expected := range map[slog.Level]rfc5424.Severity{
+		slog.LevelDebug - 4:   rfc5424.SeverityDebug,
+		slog.LevelDebug:       rfc5424.SeverityDebug,
+		slog.LevelInfo:        rfc5424.SeverityInformational,
+		slog.LevelInfo + 2:    rfc5424.SeverityNotice,
+		slog.LevelWarn + 2:    rfc5424.SeverityWarning,
+		slog.LevelError:       rfc5424.SeverityError,
+		slog.LevelError + 4:   rfc5424.SeverityCrit,
+		slog.LevelError + 8:   rfc5424.SeverityAlert,
+		slog.LevelError + 100: rfc5424.SeverityEmergency,
+	} {
+		c.Logf("trying %s", level)
+		c.Check(levels.FromSlog(level), gc.Equals, expected)
+	}
+}
+
+func (s *LevelsSuite) TestLoggo(c *gc.C) {
+	for level, expected := range map[loggo.Level]rfc5424.Severity{
+		loggo.UNSPECIFIED: rfc5424.SeverityInformational,
+		loggo.TRACE:       rfc5424.SeverityDebug,
+		loggo.DEBUG:       rfc5424.SeverityDebug,
+		loggo.INFO:        rfc5424.SeverityInformational,
+		loggo.WARNING:     rfc5424.SeverityWarning,
+		loggo.ERROR:       rfc5424.SeverityError,
+		loggo.CRITICAL:    rfc5424.SeverityCrit,
+	} {
+		c.Logf("trying %s", level)
+		c.Check(levels.FromLoggo(level), gc.Equals, expected)
+		if level != loggo.UNSPECIFIED && level != loggo.TRACE {
+			c.Check(levels.ToLoggo(expected), gc.Equals, level)
+		}
+	}
+	c.Check(levels.ToLoggo(rfc5424.SeverityNotice), gc.Equals, loggo.INFO)
+	c.Check(levels.ToLoggo(rfc5424.SeverityEmergency), gc.Equals, loggo.CRITICAL)
+}
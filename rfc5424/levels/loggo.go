@@ -0,0 +1,64 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package levels
+
+import (
+	"github.com/juju/loggo"
+
+	"github.com/juju/rfc/v2/rfc5424"
+)
+
+// ToLoggo returns the loggo level for the severity. loggo has fewer
+// levels, so this is lossy:
+//
+//	EMERGENCY  CRITICAL
+//	ALERT      CRITICAL
+//	CRIT       CRITICAL
+//	ERROR      ERROR
+//	WARNING    WARNING
+//	NOTICE     INFO
+//	INFO       INFO
+//	DEBUG      DEBUG
+func ToLoggo(sev rfc5424.Severity) loggo.Level {
+	switch sev {
+	case rfc5424.SeverityEmergency, rfc5424.SeverityAlert, rfc5424.SeverityCrit:
+		return loggo.CRITICAL
+	case rfc5424.SeverityError:
+		return loggo.ERROR
+	case rfc5424.SeverityWarning:
+		return loggo.WARNING
+	case rfc5424.SeverityDebug:
+		return loggo.DEBUG
+	default:
+		return loggo.INFO
+	}
+}
+
+// FromLoggo returns the severity for the loggo level:
+//
+//	TRACE        DEBUG
+//	DEBUG        DEBUG
+//	INFO         INFO
+//	WARNING      WARNING
+//	ERROR        ERROR
+//	CRITICAL     CRIT
+//	UNSPECIFIED  INFO
+//
+// Syslog has no level below DEBUG, so TRACE is indistinguishable from
+// DEBUG once converted. Since ToLoggo is lossy, converting NOTICE,
+// ALERT or EMERGENCY to loggo and back does not return the original.
+func FromLoggo(level loggo.Level) rfc5424.Severity {
+	switch level {
+	case loggo.TRACE, loggo.DEBUG:
+		return rfc5424.SeverityDebug
+	case loggo.WARNING:
+		return rfc5424.SeverityWarning
+	case loggo.ERROR:
+		return rfc5424.SeverityError
+	case loggo.CRITICAL:
+		return rfc5424.SeverityCrit
+	default:
+		return rfc5424.SeverityInformational
+	}
+}
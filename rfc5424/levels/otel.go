@@ -0,0 +1,88 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package levels
+
+import (
+	"github.com/juju/rfc/v2/rfc5424"
+)
+
+// These are the OpenTelemetry severity numbers that ToOTel uses. Each
+// is the first number of its range, e.g. 9 to 12 are all INFO.
+//
+// See https://opentelemetry.io/docs/specs/otel/logs/data-model/#field-severitynumber.
+const (
+	OTelUnspecified = 0
+	OTelTrace       = 1
+	OTelDebug       = 5
+	OTelInfo        = 9
+	OTelWarn        = 13
+	OTelError       = 17
+	OTelFatal       = 21
+)
+
+// ToOTel returns the OpenTelemetry SeverityNumber for the severity,
+// following the syslog mapping in the OpenTelemetry data model:
+//
+//	EMERGENCY  21 (FATAL)
+//	ALERT      19 (ERROR3)
+//	CRIT       18 (ERROR2)
+//	ERROR      17 (ERROR)
+//	WARNING    13 (WARN)
+//	NOTICE     10 (INFO2)
+//	INFO        9 (INFO)
+//	DEBUG       5 (DEBUG)
+func ToOTel(sev rfc5424.Severity) int {
+	switch sev {
+	case rfc5424.SeverityEmergency:
+		return OTelFatal
+	case rfc5424.SeverityAlert:
+		return OTelError + 2
+	case rfc5424.SeverityCrit:
+		return OTelError + 1
+	case rfc5424.SeverityError:
+		return OTelError
+	case rfc5424.SeverityWarning:
+		return OTelWarn
+	case rfc5424.SeverityNotice:
+		return OTelInfo + 1
+	case rfc5424.SeverityDebug:
+		return OTelDebug
+	default:
+		return OTelInfo
+	}
+}
+
+// FromOTel returns the severity for an OpenTelemetry SeverityNumber.
+// It is the reverse of ToOTel, with these lossy cases:
+//
+//	1-8 (TRACE, DEBUG)  DEBUG
+//	11-12 (INFO3-4)     NOTICE
+//	14-16 (WARN2-4)     WARNING
+//	20 (ERROR4)         ALERT
+//	22-24 (FATAL2-4)    EMERGENCY
+//
+// The unspecified value (0) is INFO, as are negative numbers. Numbers
+// above 24 are EMERGENCY.
+func FromOTel(number int) rfc5424.Severity {
+	switch {
+	case number <= OTelUnspecified:
+		return rfc5424.SeverityInformational
+	case number < OTelInfo:
+		return rfc5424.SeverityDebug
+	case number == OTelInfo:
+		return rfc5424.SeverityInformational
+	case number < OTelWarn:
+		return rfc5424.SeverityNotice
+	case number < OTelError:
+		return rfc5424.SeverityWarning
+	case number == OTelError:
+		return rfc5424.SeverityError
+	case number == OTelError+1:
+		return rfc5424.SeverityCrit
+	case number < OTelFatal:
+		return rfc5424.SeverityAlert
+	default:
+		return rfc5424.SeverityEmergency
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package levels
+
+import (
+	"log/slog"
+
+	"github.com/juju/rfc/v2/rfc5424"
+)
+
+// ToSlog returns the slog level for the severity:
+//
+//	EMERGENCY  LevelError+12
+//	ALERT      LevelError+8
+//	CRIT       LevelError+4
+//	ERROR      LevelError
+//	WARNING    LevelWarn
+//	NOTICE     LevelInfo+2
+//	INFO       LevelInfo
+//	DEBUG      LevelDebug
+func ToSlog(sev rfc5424.Severity) slog.Level {
+	switch sev {
+	case rfc5424.SeverityEmergency:
+		return slog.LevelError + 12
+	case rfc5424.SeverityAlert:
+		return slog.LevelError + 8
+	case rfc5424.SeverityCrit:
+		return slog.LevelError + 4
+	case rfc5424.SeverityError:
+		return slog.LevelError
+	case rfc5424.SeverityWarning:
+		return slog.LevelWarn
+	case rfc5424.SeverityNotice:
+		return slog.LevelInfo + 2
+	case rfc5424.SeverityDebug:
+		return slog.LevelDebug
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// FromSlog returns the severity for the slog level. It is the reverse
+// of ToSlog, with levels in between rounding down, e.g. LevelWarn+2 is
+// WARNING. Levels below LevelInfo are all DEBUG.
+func FromSlog(level slog.Level) rfc5424.Severity {
+	switch {
+	case level < slog.LevelInfo:
+		return rfc5424.SeverityDebug
+	case level < slog.LevelInfo+2:
+		return rfc5424.SeverityInformational
+	case level < slog.LevelWarn:
+		return rfc5424.SeverityNotice
+	case level < slog.LevelError:
+		return rfc5424.SeverityWarning
+	case level < slog.LevelError+4:
+		return rfc5424.SeverityError
+	case level < slog.LevelError+8:
+		return rfc5424.SeverityCrit
+	case level < slog.LevelError+12:
+		return rfc5424.SeverityAlert
+	default:
+		return rfc5424.SeverityEmergency
+	}
+}
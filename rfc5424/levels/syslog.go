@@ -0,0 +1,33 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+//go:build !windows && !plan9
+
+package levels
+
+import (
+	"log/syslog"
+
+	"github.com/juju/rfc/v2/rfc5424"
+)
+
+// ToSyslog returns the log/syslog priority, which combines facility
+// and severity, for the priority. There is no loss, although
+// log/syslog has no names for facilities 12 to 15. An invalid
+// facility is treated as "user".
+func ToSyslog(p rfc5424.Priority) syslog.Priority {
+	fac := p.Facility
+	if err := fac.Validate(); err != nil || fac == 0 {
+		fac = rfc5424.FacilityUser
+	}
+	return syslog.Priority(fac-rfc5424.FacilityKern)<<3 | syslog.Priority(p.Severity-rfc5424.SeverityEmergency)
+}
+
+// FromSyslog returns the priority for the log/syslog priority. Since
+// log/syslog uses the same codes, there is no loss.
+func FromSyslog(p syslog.Priority) rfc5424.Priority {
+	return rfc5424.Priority{
+		Severity: rfc5424.SeverityEmergency + rfc5424.Severity(p&0x07),
+		Facility: rfc5424.FacilityKern + rfc5424.Facility(p>>3),
+	}
+}
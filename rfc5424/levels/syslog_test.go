@@ -0,0 +1,32 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+//go:build !windows && !plan9
+
+package levels_test
+
+import (
+	"log/syslog"
+
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/rfc/v2/rfc5424"
+	"github.com/juju/rfc/v2/rfc5424/levels"
+)
+
+func (s *LevelsSuite) TestSyslog(c *gc.C) {
+	c.Check(levels.ToSyslog(rfc5424.Priority{
+		Severity: rfc5424.SeverityWarning,
+		Facility: rfc5424.FacilityLocal3,
+	}), gc.Equals, syslog.LOG_WARNING|syslog.LOG_LOCAL3)
+	c.Check(levels.ToSyslog(rfc5424.Priority{
+		Severity: rfc5424.SeverityDebug,
+	}), gc.Equals, syslog.LOG_DEBUG|syslog.LOG_USER)
+
+	for _, sev := range allSeverities {
+		for fac := rfc5424.FacilityKern; fac <= rfc5424.FacilityLocal7; fac++ {
+			p := rfc5424.Priority{Severity: sev, Facility: fac}
+			c.Check(levels.FromSyslog(levels.ToSyslog(p)), gc.Equals, p)
+		}
+	}
+}
@@ -6,6 +6,8 @@ package rfc5424
 import (
 	"fmt"
 	"net"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -24,6 +26,53 @@ type Message struct {
 	Msg string
 }
 
+// utf8BOM is the byte order mark that may prefix a UTF-8 MSG.
+const utf8BOM = "\xef\xbb\xbf"
+
+// ParseMessage converts the RFC 5424 representation of a log record
+// back into a Message. Structured data elements are returned as
+// GenericStructuredDataElement values and a leading BOM is stripped
+// from the MSG part. To keep the original text as well, use
+// ParseRawMessage.
+func ParseMessage(str string) (Message, error) {
+	var raw RawMessage
+	m, _, err := parseMessage(str, &raw)
+	return m, err
+}
+
+// parseMessage parses the message, recording the text of each part in
+// raw. It also returns where the structured data starts in the text.
+func parseMessage(str string, raw *RawMessage) (Message, int, error) {
+	var m Message
+
+	header, rest, err := parseHeader(str, raw)
+	if err != nil {
+		return m, 0, fmt.Errorf("bad Header: %v", err)
+	}
+	m.Header = header
+
+	sdStart := len(str) - len(rest)
+	sd, rest, err := parseStructuredData(rest)
+	if err != nil {
+		return m, 0, fmt.Errorf("bad StructuredData: %v", err)
+	}
+	m.StructuredData = sd
+	raw.StructuredData = str[sdStart : len(str)-len(rest)]
+
+	if rest != "" {
+		if rest[0] != ' ' {
+			return m, 0, fmt.Errorf("missing space before Msg")
+		}
+		raw.Msg = rest[1:]
+		m.Msg = strings.TrimPrefix(raw.Msg, utf8BOM)
+	}
+
+	if err := m.Validate(); err != nil {
+		return m, 0, err
+	}
+	return m, sdStart, nil
+}
+
 // String returns the RFC 5424 representation of the log record.
 func (m Message) String() string {
 	if m.Msg == "" {
@@ -100,6 +149,57 @@ func (h Header) Validate() error {
 	return nil
 }
 
+func parseHeader(str string, raw *RawMessage) (Header, string, error) {
+	var h Header
+
+	end := strings.IndexByte(str, '>')
+	if !strings.HasPrefix(str, "<") || end < 0 {
+		return h, "", fmt.Errorf("missing Priority")
+	}
+	priority, err := ParsePriority(str[:end+1])
+	if err != nil {
+		return h, "", fmt.Errorf("bad Priority: %v", err)
+	}
+	h.Priority = priority
+	raw.Priority = str[:end+1]
+	str = str[end+1:]
+
+	fields := strings.SplitN(str, " ", 7)
+	if len(fields) < 6 {
+		return h, "", fmt.Errorf("expected 7 fields, got %d", len(fields)+1)
+	}
+	if version, err := strconv.Atoi(fields[0]); err != nil || version != ProtocolVersion {
+		return h, "", fmt.Errorf("unsupported version %q", fields[0])
+	}
+	if h.Timestamp, err = ParseTimestamp(fields[1]); err != nil {
+		return h, "", fmt.Errorf("bad Timestamp: %v", err)
+	}
+	h.Hostname = ParseHostname(fields[2])
+	h.AppName = AppName(nilValue(fields[3]))
+	h.ProcID = ProcID(nilValue(fields[4]))
+	h.MsgID = MsgID(nilValue(fields[5]))
+	raw.Version = fields[0]
+	raw.Timestamp = fields[1]
+	raw.Hostname = fields[2]
+	raw.AppName = fields[3]
+	raw.ProcID = fields[4]
+	raw.MsgID = fields[5]
+
+	var rest string
+	if len(fields) == 7 {
+		rest = fields[6]
+	}
+	return h, rest, nil
+}
+
+// nilValue maps the RFC 5424 NILVALUE ("-") to the empty string.
+func nilValue(str string) string {
+	if str == "-" {
+		return ""
+	}
+	return str
+}
+
 // Timestamp is an RFC 5424 timestamp.
 type Timestamp struct {
 	time.Time
@@ -115,6 +215,19 @@ func (t Timestamp) String() string { // essentially RFC 3339
 	return t.Format(time.RFC3339Nano)
 }
 
+// ParseTimestamp converts the RFC 5424 representation of a timestamp
+// back into a Timestamp. "-" results in the zero value.
+func ParseTimestamp(str string) (Timestamp, error) {
+	if str == "-" {
+		return Timestamp{}, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, str)
+	if err != nil {
+		return Timestamp{}, err
+	}
+	return Timestamp{t}, nil
+}
+
 var zeroIP net.IP
 
 // Hostname hold the different possible values for an RFC 5424 value.
@@ -155,6 +268,23 @@ func (h Hostname) String() string {
 	}
 }
 
+// ParseHostname converts the RFC 5424 representation of a hostname
+// back into a Hostname. Since the wire format does not distinguish
+// between them, an IP address is always treated as static and a name
+// containing a dot as fully-qualified.
+func ParseHostname(str string) Hostname {
+	switch {
+	case str == "-" || str == "":
+		return Hostname{}
+	case net.ParseIP(str) != nil:
+		return Hostname{StaticIP: net.ParseIP(str)}
+	case strings.Contains(str, "."):
+		return Hostname{FQDN: str}
+	default:
+		return Hostname{Hostname: str}
+	}
+}
+
 // Validate ensures that the hostname is correct.
 func (h Hostname) Validate() error {
 	switch {
@@ -186,3 +186,119 @@ func (s *MessageSuite) TestValidateBadMessage(c *gc.C) {
 
 	c.Check(err, gc.ErrorMatches, `bad Msg: invalid UTF-8`)
 }
+
+func (s *MessageSuite) TestParseMessageFull(c *gc.C) {
+	msg, err := rfc5424.ParseMessage(`<28>1 1970-01-01T15:05:21.000000123Z a.b.org an-app 119 xyz... [spam x="y"] a message`)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(msg, jc.DeepEquals, rfc5424.Message{
+		Header: rfc5424.Header{
+			Priority: rfc5424.Priority{
+				Severity: rfc5424.SeverityWarning,
+				Facility: rfc5424.FacilityDaemon,
+			},
+			Timestamp: rfc5424.Timestamp{time.Unix(54321, 123).UTC()},
+			Hostname:  rfc5424.Hostname{FQDN: "a.b.org"},
+			AppName:   "an-app",
+			ProcID:    "119",
+			MsgID:     "xyz...",
+		},
+		StructuredData: rfc5424.StructuredData{
+			rfc5424.GenericStructuredDataElement{
+				SDID: "spam",
+				Data: []rfc5424.StructuredDataParam{{Name: "x", Value: "y"}},
+			},
+		},
+		Msg: "a message",
+	})
+}
+
+func (s *MessageSuite) TestParseMessageZeroValue(c *gc.C) {
+	msg, err := rfc5424.ParseMessage("<8>1 - - - - - -")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(msg, jc.DeepEquals, rfc5424.Message{
+		Header: rfc5424.Header{
+			Priority: rfc5424.Priority{
+				Severity: rfc5424.SeverityEmergency,
+				Facility: rfc5424.FacilityUser,
+			},
+		},
+	})
+}
+
+func (s *MessageSuite) TestParseMessageRoundTrip(c *gc.C) {
+	stub := &testing.Stub{}
+	orig := rfc5424.Message{
+		Header: rfc5424.Header{
+			Priority: rfc5424.Priority{
+				Severity: rfc5424.SeverityDebug,
+				Facility: rfc5424.FacilityLocal3,
+			},
+			Timestamp: rfc5424.Timestamp{time.Unix(54321, 0).UTC()},
+			Hostname:  rfc5424.Hostname{Hostname: "a"},
+			AppName:   "an-app",
+		},
+		StructuredData: rfc5424.StructuredData{
+			newStubElement(stub, "spam", "x=y", "w=z"),
+			newStubElement(stub, "eggs"),
+		},
+		Msg: "a message  with  spaces ",
+	}
+
+	msg, err := rfc5424.ParseMessage(orig.String())
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(msg.String(), gc.Equals, orig.String())
+	c.Check(msg.Header, jc.DeepEquals, orig.Header)
+	c.Check(msg.Msg, gc.Equals, orig.Msg)
+}
+
+func (s *MessageSuite) TestParseMessageBOM(c *gc.C) {
+	msg, err := rfc5424.ParseMessage("<8>1 - - - - - - \xef\xbb\xbfa message")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(msg.Msg, gc.Equals, "a message")
+}
+
+func (s *MessageSuite) TestParseMessageIP(c *gc.C) {
+	msg, err := rfc5424.ParseMessage("<8>1 - 10.3.2.1 - - - -")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(msg.Hostname.StaticIP.String(), gc.Equals, "10.3.2.1")
+}
+
+func (s *MessageSuite) TestParseMessageErrors(c *gc.C) {
+	for i, test := range []struct {
+		str string
+		err string
+	}{{
+		str: "",
+		err: `bad Header: missing Priority`,
+	}, {
+		str: "<999>1 - - - - - -",
+		err: `bad Header: bad Priority: bad Facility: facility 125 not recognized`,
+	}, {
+		str: "<8>2 - - - - - -",
+		err: `bad Header: unsupported version "2"`,
+	}, {
+		str: "<8>1 - - - -",
+		err: `bad Header: expected 7 fields, got 6`,
+	}, {
+		str: "<8>1 yesterday - - - - -",
+		err: `bad Header: bad Timestamp: .*`,
+	}, {
+		str: "<8>1 - - - - - x",
+		err: `bad StructuredData: expected "-" or "\["`,
+	}, {
+		str: `<8>1 - - - - - [spam x="y]`,
+		err: `bad StructuredData: element 0 not valid: param 0 not valid: unterminated Value for "x"`,
+	}, {
+		str: `<8>1 - - - - - [spam x="y"]msg`,
+		err: `missing space before Msg`,
+	}} {
+		c.Logf("test %d: %q", i, test.str)
+		_, err := rfc5424.ParseMessage(test.str)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
@@ -0,0 +1,266 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package rfc5424
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+)
+
+// Balancing is how a MultiClient chooses between its hosts.
+type Balancing int
+
+const (
+	// BalanceFailover sends every message to one host, moving on to
+	// the next in order when it fails.
+	BalanceFailover Balancing = iota
+
+	// BalanceRoundRobin sends each message to the next host in turn.
+	BalanceRoundRobin
+)
+
+// String returns the name of the balancing mode.
+func (b Balancing) String() string {
+	switch b {
+	case BalanceFailover:
+		return "failover"
+	case BalanceRoundRobin:
+		return "round-robin"
+	default:
+		return fmt.Sprintf("Balancing(%d)", int(b))
+	}
+}
+
+// MultiClientConfig is the configuration for a MultiClient.
+type MultiClientConfig struct {
+	// ClientConfig is used for the client of each host.
+	ClientConfig
+
+	// Hosts holds the addresses of the collectors, in order of
+	// preference.
+	Hosts []string
+
+	// Dial is used to connect to each host. If it is nil then
+	// net.Dial is used, as for Open.
+	Dial DialFunc
+
+	// Balancing is how messages are spread over the hosts.
+	Balancing Balancing
+
+	// FailbackInterval is how long a host that failed is avoided. Once
+	// it has passed the host is tried again, so with BalanceFailover
+	// messages go back to a preferred host once it has recovered. If
+	// it is not set then a failed host is only tried again once the
+	// hosts after it have failed.
+	FailbackInterval time.Duration
+
+	// Clock is used to time FailbackInterval. If it is nil then the
+	// wall clock is used.
+	Clock clock.Clock
+}
+
+// Validate ensures that the config is correct.
+func (cfg MultiClientConfig) Validate() error {
+	if len(cfg.Hosts) == 0 {
+		return errors.NotValidf("no Hosts")
+	}
+	for i, host := range cfg.Hosts {
+		if host == "" {
+			return errors.NotValidf("empty Hosts[%d]", i)
+		}
+	}
+	if cfg.Balancing != BalanceFailover && cfg.Balancing != BalanceRoundRobin {
+		return errors.NotValidf("Balancing %v", cfg.Balancing)
+	}
+	if cfg.FailbackInterval < 0 {
+		return errors.NotValidf("negative FailbackInterval")
+	}
+	return nil
+}
+
+// endpoint is one of a MultiClient's hosts.
+type endpoint struct {
+	host   string
+	client *Client
+
+	// failed is when sending to the host last failed.
+	failed time.Time
+}
+
+// MultiClient sends syslog messages to one of several hosts, so that
+// losing a collector does not stop messages from being sent. Each host
+// is connected to when it is first needed and a connection that fails
+// is closed and reopened when the host is next tried. MultiClient is
+// safe for concurrent use.
+type MultiClient struct {
+	cfg       MultiClientConfig
+	endpoints []*endpoint
+
+	mu     sync.Mutex
+	closed bool
+
+	// current is the index of the host last sent to.
+	current int
+
+	// next is the index of the host that round-robin balancing
+	// tries first.
+	next int
+}
+
+var (
+	_ Sender    = (*MultiClient)(nil)
+	_ RawSender = (*MultiClient)(nil)
+)
+
+// OpenMulti returns a client for the configured hosts. It fails if
+// none of them can be connected to.
+func OpenMulti(cfg MultiClientConfig) (*MultiClient, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if cfg.Clock == nil {
+		cfg.Clock = clock.WallClock
+	}
+	mc := &MultiClient{cfg: cfg}
+	for _, host := range cfg.Hosts {
+		mc.endpoints = append(mc.endpoints, &endpoint{host: host})
+	}
+
+	var lastErr error
+	for _, i := range mc.order() {
+		ep := mc.endpoints[i]
+		if lastErr = mc.connect(ep); lastErr == nil {
+			mc.current = i
+			return mc, nil
+		}
+		ep.failed = cfg.Clock.Now()
+	}
+	return nil, errors.Annotate(lastErr, "connecting to all hosts failed")
+}
+
+// Host returns the address of the host that the last message was sent
+// to, or that was connected to first.
+func (mc *MultiClient) Host() string {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	return mc.endpoints[mc.current].host
+}
+
+// Close closes the connections to all of the hosts. Messages cannot
+// be sent once it has been called.
+func (mc *MultiClient) Close() error {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	mc.closed = true
+
+	var firstErr error
+	for _, ep := range mc.endpoints {
+		if ep.client == nil {
+			continue
+		}
+		if err := ep.client.Close(); err != nil && firstErr == nil {
+			firstErr = errors.Annotatef(err, "closing %q", ep.host)
+		}
+		ep.client = nil
+	}
+	return firstErr
+}
+
+// Send implements Sender. The message is sent to the first host that
+// accepts it, trying each host at most once.
+func (mc *MultiClient) Send(msg Message) error {
+	return mc.sendAny(func(client *Client) error {
+		return client.Send(msg)
+	})
+}
+
+// SendRaw implements RawSender. The parsed message is sent as for
+// Send, exactly as it was received (see Client.SendRaw).
+func (mc *MultiClient) SendRaw(raw *RawMessage) error {
+	return mc.sendAny(func(client *Client) error {
+		return client.SendRaw(raw)
+	})
+}
+
+// sendAny calls send with the client of the first host that accepts
+// the message.
+func (mc *MultiClient) sendAny(send func(*Client) error) error {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	if mc.closed {
+		return errors.New("multi client closed")
+	}
+
+	var lastErr error
+	for _, i := range mc.order() {
+		ep := mc.endpoints[i]
+		if lastErr = mc.send(ep, send); lastErr == nil {
+			mc.current = i
+			mc.next = (i + 1) % len(mc.endpoints)
+			return nil
+		}
+		ep.failed = mc.cfg.Clock.Now()
+	}
+	return errors.Annotate(lastErr, "sending to all hosts failed")
+}
+
+func (mc *MultiClient) send(ep *endpoint, send func(*Client) error) error {
+	if err := mc.connect(ep); err != nil {
+		return errors.Trace(err)
+	}
+	if err := send(ep.client); err != nil {
+		ep.client.Close()
+		ep.client = nil
+		return errors.Annotatef(err, "sending to %q", ep.host)
+	}
+	return nil
+}
+
+func (mc *MultiClient) connect(ep *endpoint) error {
+	if ep.client != nil {
+		return nil
+	}
+	client, err := Open(ep.host, mc.cfg.ClientConfig, mc.cfg.Dial)
+	if err != nil {
+		return errors.Annotatef(err, "connecting to %q", ep.host)
+	}
+	ep.client = client
+	return nil
+}
+
+// order returns the indexes of the hosts in the order they should be
+// tried. Hosts that failed within the failback interval come last.
+func (mc *MultiClient) order() []int {
+	n := len(mc.endpoints)
+	start := 0
+	switch {
+	case mc.cfg.Balancing == BalanceRoundRobin:
+		start = mc.next
+	case mc.cfg.FailbackInterval == 0:
+		start = mc.current
+	}
+
+	var up, down []int
+	now := mc.cfg.Clock.Now()
+	for j := 0; j < n; j++ {
+		i := (start + j) % n
+		if mc.isDown(mc.endpoints[i], now) {
+			down = append(down, i)
+		} else {
+			up = append(up, i)
+		}
+	}
+	return append(up, down...)
+}
+
+func (mc *MultiClient) isDown(ep *endpoint, now time.Time) bool {
+	if ep.failed.IsZero() || mc.cfg.FailbackInterval == 0 {
+		return false
+	}
+	return now.Before(ep.failed.Add(mc.cfg.FailbackInterval))
+}
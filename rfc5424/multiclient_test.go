@@ -0,0 +1,218 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package rfc5424_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/rfc/v2/rfc5424"
+)
+
+type MultiClientSuite struct {
+	testing.IsolationSuite
+
+	clock *testclock.Clock
+
+	// down holds the hosts that cannot be dialled or written to.
+	down map[string]bool
+
+	// sent holds the host each message was written to.
+	sent []string
+}
+
+var _ = gc.Suite(&MultiClientSuite{})
+
+func (s *MultiClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Unix(54321, 0))
+	s.down = make(map[string]bool)
+	s.sent = nil
+}
+
+func (s *MultiClientSuite) dial(network, address string) (rfc5424.Conn, error) {
+	if s.down[address] {
+		return nil, errors.Errorf("connection refused")
+	}
+	return &hostConn{suite: s, host: address}, nil
+}
+
+func (s *MultiClientSuite) config(balancing rfc5424.Balancing) rfc5424.MultiClientConfig {
+	return rfc5424.MultiClientConfig{
+		Hosts:     []string{"a:514", "b:514", "c:514"},
+		Dial:      s.dial,
+		Balancing: balancing,
+		Clock:     s.clock,
+	}
+}
+
+func (s *MultiClientSuite) send(c *gc.C, client *rfc5424.MultiClient, n int) {
+	for i := 0; i < n; i++ {
+		err := client.Send(rfc5424.Message{Msg: "a message"})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *MultiClientSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		change func(*rfc5424.MultiClientConfig)
+		err    string
+	}{{
+		change: func(cfg *rfc5424.MultiClientConfig) { cfg.Hosts = nil },
+		err:    "no Hosts not valid",
+	}, {
+		change: func(cfg *rfc5424.MultiClientConfig) { cfg.Hosts[1] = "" },
+		err:    `empty Hosts\[1\] not valid`,
+	}, {
+		change: func(cfg *rfc5424.MultiClientConfig) { cfg.Balancing = 7 },
+		err:    `Balancing Balancing\(7\) not valid`,
+	}, {
+		change: func(cfg *rfc5424.MultiClientConfig) { cfg.FailbackInterval = -time.Second },
+		err:    "negative FailbackInterval not valid",
+	}} {
+		c.Logf("test %d", i)
+		cfg := s.config(rfc5424.BalanceFailover)
+		test.change(&cfg)
+
+		_, err := rfc5424.OpenMulti(cfg)
+
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *MultiClientSuite) TestOpenSkipsDownHosts(c *gc.C) {
+	s.down["a:514"] = true
+
+	client, err := rfc5424.OpenMulti(s.config(rfc5424.BalanceFailover))
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(client.Host(), gc.Equals, "b:514")
+}
+
+func (s *MultiClientSuite) TestOpenAllDown(c *gc.C) {
+	s.down["a:514"] = true
+	s.down["b:514"] = true
+	s.down["c:514"] = true
+
+	_, err := rfc5424.OpenMulti(s.config(rfc5424.BalanceFailover))
+
+	c.Check(err, gc.ErrorMatches, `connecting to all hosts failed: connecting to "c:514": connection refused`)
+}
+
+func (s *MultiClientSuite) TestFailover(c *gc.C) {
+	client, err := rfc5424.OpenMulti(s.config(rfc5424.BalanceFailover))
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.send(c, client, 2)
+	s.down["a:514"] = true
+	s.send(c, client, 2)
+	s.down["b:514"] = true
+	s.send(c, client, 1)
+	s.down["a:514"] = false
+	s.send(c, client, 1)
+
+	// Without a failback interval, a is not used again until c fails.
+	c.Check(s.sent, jc.DeepEquals, []string{"a:514", "a:514", "b:514", "b:514", "c:514", "c:514"})
+	c.Check(client.Host(), gc.Equals, "c:514")
+}
+
+func (s *MultiClientSuite) TestFailback(c *gc.C) {
+	cfg := s.config(rfc5424.BalanceFailover)
+	cfg.FailbackInterval = time.Minute
+	client, err := rfc5424.OpenMulti(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.down["a:514"] = true
+	s.send(c, client, 1)
+	s.down["a:514"] = false
+	s.clock.Advance(30 * time.Second)
+	s.send(c, client, 1)
+	s.clock.Advance(30 * time.Second)
+	s.send(c, client, 1)
+
+	c.Check(s.sent, jc.DeepEquals, []string{"b:514", "b:514", "a:514"})
+}
+
+func (s *MultiClientSuite) TestRoundRobin(c *gc.C) {
+	cfg := s.config(rfc5424.BalanceRoundRobin)
+	cfg.FailbackInterval = time.Minute
+	client, err := rfc5424.OpenMulti(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.send(c, client, 4)
+	s.down["b:514"] = true
+	s.send(c, client, 3)
+	s.down["b:514"] = false
+	s.clock.Advance(time.Minute)
+	s.send(c, client, 3)
+
+	c.Check(s.sent, jc.DeepEquals, []string{
+		"a:514", "b:514", "c:514", "a:514",
+		"c:514", "a:514", "c:514",
+		"a:514", "b:514", "c:514",
+	})
+}
+
+func (s *MultiClientSuite) TestSendAllDown(c *gc.C) {
+	client, err := rfc5424.OpenMulti(s.config(rfc5424.BalanceFailover))
+	c.Assert(err, jc.ErrorIsNil)
+	s.down["a:514"] = true
+	s.down["b:514"] = true
+	s.down["c:514"] = true
+
+	err = client.Send(rfc5424.Message{Msg: "a message"})
+
+	c.Check(err, gc.ErrorMatches, `sending to all hosts failed: connecting to "c:514": connection refused`)
+	c.Check(s.sent, gc.HasLen, 0)
+}
+
+func (s *MultiClientSuite) TestClose(c *gc.C) {
+	client, err := rfc5424.OpenMulti(s.config(rfc5424.BalanceRoundRobin))
+	c.Assert(err, jc.ErrorIsNil)
+	s.send(c, client, 1)
+
+	err = client.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	err = client.Close()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *MultiClientSuite) TestSendAfterClose(c *gc.C) {
+	client, err := rfc5424.OpenMulti(s.config(rfc5424.BalanceFailover))
+	c.Assert(err, jc.ErrorIsNil)
+	err = client.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = client.Send(rfc5424.Message{Msg: "a message"})
+
+	c.Check(err, gc.ErrorMatches, "multi client closed")
+	c.Check(s.sent, gc.HasLen, 0)
+}
+
+type hostConn struct {
+	suite *MultiClientSuite
+	host  string
+}
+
+func (conn *hostConn) Close() error {
+	return nil
+}
+
+func (conn *hostConn) Write(data []byte) (int, error) {
+	if conn.suite.down[conn.host] {
+		return 0, errors.Errorf("broken pipe")
+	}
+	conn.suite.sent = append(conn.suite.sent, conn.host)
+	return len(data), nil
+}
+
+func (conn *hostConn) SetWriteDeadline(time.Time) error {
+	return nil
+}
@@ -0,0 +1,10 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+// The otellog package converts RFC 5424 syslog messages to and from
+// log records shaped like the OpenTelemetry log data model. It has no
+// dependency on the OpenTelemetry SDK, so records can be handed to any
+// exporter, or kept in memory in tests.
+//
+// See https://opentelemetry.io/docs/specs/otel/logs/data-model/.
+package otellog
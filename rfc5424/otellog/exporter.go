@@ -0,0 +1,65 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package otellog
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/rfc/v2/rfc5424"
+)
+
+// Exporter is the part of an OpenTelemetry log exporter that Sender
+// needs. A thin wrapper around an SDK exporter satisfies it.
+type Exporter interface {
+	// Export exports the records.
+	Export(ctx context.Context, records []Record) error
+}
+
+// Sender is an rfc5424.Sender that converts each message into a record
+// and exports it.
+type Sender struct {
+	exporter Exporter
+}
+
+var _ rfc5424.Sender = (*Sender)(nil)
+
+// NewSender returns a Sender that exports to the exporter.
+func NewSender(exporter Exporter) *Sender {
+	return &Sender{exporter: exporter}
+}
+
+// Send implements rfc5424.Sender. The record's ObservedTimestamp is
+// the time of the call.
+func (s *Sender) Send(msg rfc5424.Message) error {
+	rec := FromMessage(msg)
+	rec.ObservedTimestamp = time.Now()
+	err := s.exporter.Export(context.Background(), []Record{rec})
+	return errors.Trace(err)
+}
+
+// MemoryExporter is an Exporter that keeps every record it is given.
+// It can stand in for a collector in tests.
+type MemoryExporter struct {
+	mu      sync.Mutex
+	records []Record
+}
+
+// Export implements Exporter.
+func (e *MemoryExporter) Export(_ context.Context, records []Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.records = append(e.records, records...)
+	return nil
+}
+
+// Records returns a copy of the exported records.
+func (e *MemoryExporter) Records() []Record {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]Record(nil), e.records...)
+}
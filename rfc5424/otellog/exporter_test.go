@@ -0,0 +1,51 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package otellog_test
+
+import (
+	"log/slog"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/rfc/v2/rfc5424/otellog"
+	"github.com/juju/rfc/v2/rfc5424/rfc5424slog"
+)
+
+type SenderSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&SenderSuite{})
+
+func (s *SenderSuite) TestSend(c *gc.C) {
+	exporter := &otellog.MemoryExporter{}
+	sender := otellog.NewSender(exporter)
+
+	err := sender.Send(newMessage())
+	c.Assert(err, jc.ErrorIsNil)
+
+	records := exporter.Records()
+	c.Assert(records, gc.HasLen, 1)
+	c.Check(records[0].ObservedTimestamp.IsZero(), jc.IsFalse)
+	records[0].ObservedTimestamp = newRecord().ObservedTimestamp
+	c.Check(records[0], jc.DeepEquals, newRecord())
+}
+
+func (s *SenderSuite) TestSlogPipeline(c *gc.C) {
+	exporter := &otellog.MemoryExporter{}
+	handler, err := rfc5424slog.NewHandler(otellog.NewSender(exporter), rfc5424slog.Options{PEN: 32473})
+	c.Assert(err, jc.ErrorIsNil)
+
+	slog.New(handler).Error("failed", "attempt", 3)
+
+	records := exporter.Records()
+	c.Assert(records, gc.HasLen, 1)
+	c.Check(records[0].Body, gc.Equals, "failed")
+	c.Check(records[0].SeverityNumber, gc.Equals, 17)
+	c.Check(records[0].Attributes["structured_data"], jc.DeepEquals, map[string]interface{}{
+		"slog@32473": map[string]interface{}{"attempt": "3"},
+	})
+}
@@ -0,0 +1,266 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package otellog
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/rfc/v2/rfc5424"
+	"github.com/juju/rfc/v2/rfc5424/levels"
+)
+
+// These are the resource attribute keys that are set from the header,
+// as defined by the OpenTelemetry semantic conventions.
+const (
+	ResourceHostName    = "host.name"
+	ResourceServiceName = "service.name"
+)
+
+// These are the log record attribute keys for the parts of a message,
+// as set by the OpenTelemetry Collector's syslog receiver.
+const (
+	// AttrHostname holds the HOSTNAME.
+	AttrHostname = "hostname"
+
+	// AttrAppName holds the APP-NAME.
+	AttrAppName = "appname"
+
+	// AttrProcID holds the PROCID, as a string.
+	AttrProcID = "proc_id"
+
+	// AttrMsgID holds the MSGID.
+	AttrMsgID = "msg_id"
+
+	// AttrFacility holds the facility code as an int64, e.g. 3 for
+	// daemon.
+	AttrFacility = "facility"
+
+	// AttrStructuredData holds the structured data as a map from
+	// SD-ID to a map of param names to values. A param that appears
+	// more than once has a []interface{} of its values.
+	AttrStructuredData = "structured_data"
+)
+
+// Record is a log record following the OpenTelemetry log data model.
+// Attribute values are those of an OpenTelemetry AnyValue: string,
+// int64, []interface{} or map[string]interface{}.
+type Record struct {
+	// Timestamp is when the event occurred.
+	Timestamp time.Time
+
+	// ObservedTimestamp is when the record was collected.
+	ObservedTimestamp time.Time
+
+	// SeverityNumber is the OpenTelemetry severity number (see
+	// levels.ToOTel).
+	SeverityNumber int
+
+	// SeverityText is the name of the syslog severity.
+	SeverityText string
+
+	// Body is the log message.
+	Body string
+
+	// Resource describes the source of the record.
+	Resource map[string]interface{}
+
+	// Attributes holds the rest of the record's information.
+	Attributes map[string]interface{}
+}
+
+// FromMessage converts a syslog message into a record. The header and
+// structured data become record attributes, and the hostname and
+// APP-NAME are also set as resource attributes. Empty fields are left
+// out.
+func FromMessage(msg rfc5424.Message) Record {
+	rec := Record{
+		Timestamp:      msg.Timestamp.Time,
+		SeverityNumber: levels.ToOTel(msg.Severity),
+		SeverityText:   msg.Severity.String(),
+		Body:           msg.Msg,
+		Resource:       make(map[string]interface{}),
+		Attributes: map[string]interface{}{
+			AttrFacility: facilityCode(msg.Facility),
+		},
+	}
+
+	if hostname := msg.Hostname.String(); hostname != "-" {
+		rec.Resource[ResourceHostName] = hostname
+		rec.Attributes[AttrHostname] = hostname
+	}
+	if msg.AppName != "" {
+		rec.Resource[ResourceServiceName] = string(msg.AppName)
+		rec.Attributes[AttrAppName] = string(msg.AppName)
+	}
+	if msg.ProcID != "" {
+		rec.Attributes[AttrProcID] = string(msg.ProcID)
+	}
+	if msg.MsgID != "" {
+		rec.Attributes[AttrMsgID] = string(msg.MsgID)
+	}
+	if len(msg.StructuredData) > 0 {
+		sd := make(map[string]interface{})
+		for _, sde := range msg.StructuredData {
+			params, ok := sd[string(sde.ID())].(map[string]interface{})
+			if !ok {
+				params = make(map[string]interface{})
+				sd[string(sde.ID())] = params
+			}
+			for _, param := range sde.Params() {
+				addParam(params, string(param.Name), string(param.Value))
+			}
+		}
+		rec.Attributes[AttrStructuredData] = sd
+	}
+	return rec
+}
+
+func addParam(params map[string]interface{}, name, value string) {
+	switch existing := params[name].(type) {
+	case nil:
+		params[name] = value
+	case string:
+		params[name] = []interface{}{existing, value}
+	case []interface{}:
+		params[name] = append(existing, value)
+	}
+}
+
+// ToMessage converts a record back into a syslog message. The hostname
+// and APP-NAME are taken from the resource attributes if the record
+// attributes do not have them. The severity is taken from SeverityText
+// if it is a syslog severity name and from SeverityNumber otherwise
+// (see levels.FromOTel). Structured data elements are
+// GenericStructuredDataElement values, in order of SD-ID and param
+// name since attribute maps are unordered. The message is validated.
+func ToMessage(rec Record) (rfc5424.Message, error) {
+	var msg rfc5424.Message
+	msg.Severity = levels.FromOTel(rec.SeverityNumber)
+	if sev, err := rfc5424.ParseSeverity(rec.SeverityText); err == nil {
+		msg.Severity = sev
+	}
+	msg.Timestamp = rfc5424.Timestamp{Time: rec.Timestamp}
+	msg.Msg = rec.Body
+
+	if hostname, ok := stringAttr(rec, AttrHostname, ResourceHostName); ok {
+		msg.Hostname = rfc5424.ParseHostname(hostname)
+	}
+	if appName, ok := stringAttr(rec, AttrAppName, ResourceServiceName); ok {
+		msg.AppName = rfc5424.AppName(appName)
+	}
+	if procID, ok := rec.Attributes[AttrProcID].(string); ok {
+		msg.ProcID = rfc5424.ProcID(procID)
+	}
+
+	if attr, ok := rec.Attributes[AttrFacility]; ok {
+		fac, err := facility(attr)
+		if err != nil {
+			return msg, errors.NotValidf("%s attribute (%v)", AttrFacility, err)
+		}
+		msg.Facility = fac
+	}
+	if msgID, ok := rec.Attributes[AttrMsgID].(string); ok {
+		msg.MsgID = rfc5424.MsgID(msgID)
+	}
+	if sdAttr, ok := rec.Attributes[AttrStructuredData]; ok {
+		sd, err := structuredData(sdAttr)
+		if err != nil {
+			return msg, errors.NotValidf("%s attribute (%v)", AttrStructuredData, err)
+		}
+		msg.StructuredData = sd
+	}
+
+	if err := msg.Validate(); err != nil {
+		return msg, errors.NewNotValid(err, "converted message")
+	}
+	return msg, nil
+}
+
+// stringAttr returns the string record attribute, or else the string
+// resource attribute.
+func stringAttr(rec Record, attrKey, resourceKey string) (string, bool) {
+	if value, ok := rec.Attributes[attrKey].(string); ok {
+		return value, true
+	}
+	value, ok := rec.Resource[resourceKey].(string)
+	return value, ok
+}
+
+// facility returns the facility for a code, or for a name such as
+// "daemon".
+func facility(attr interface{}) (rfc5424.Facility, error) {
+	var code int64
+	switch value := attr.(type) {
+	case int64:
+		code = value
+	case int:
+		code = int64(value)
+	case string:
+		return rfc5424.ParseFacility(value)
+	default:
+		return 0, fmt.Errorf("expected int64, got %T", attr)
+	}
+	if code < 0 || code > math.MaxInt32 {
+		return 0, fmt.Errorf("unknown facility %d", code)
+	}
+	fac := rfc5424.FacilityKern + rfc5424.Facility(code)
+	if err := fac.Validate(); err != nil {
+		return 0, fmt.Errorf("unknown facility %d", code)
+	}
+	return fac, nil
+}
+
+// facilityCode returns the number used for the facility on the wire.
+// An invalid facility is treated as "user".
+func facilityCode(fac rfc5424.Facility) int64 {
+	if err := fac.Validate(); err != nil || fac == 0 {
+		fac = rfc5424.FacilityUser
+	}
+	return int64(fac - rfc5424.FacilityKern)
+}
+
+func structuredData(attr interface{}) (rfc5424.StructuredData, error) {
+	elements, ok := attr.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected map, got %T", attr)
+	}
+	var sd rfc5424.StructuredData
+	for _, id := range sortedKeys(elements) {
+		params, ok := elements[id].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("element %q: expected map, got %T", id, elements[id])
+		}
+		sde := rfc5424.GenericStructuredDataElement{
+			SDID: rfc5424.StructuredDataName(id),
+		}
+		for _, name := range sortedKeys(params) {
+			values, ok := params[name].([]interface{})
+			if !ok {
+				values = []interface{}{params[name]}
+			}
+			for _, value := range values {
+				sde.Data = append(sde.Data, rfc5424.StructuredDataParam{
+					Name:  rfc5424.StructuredDataName(name),
+					Value: rfc5424.StructuredDataParamValue(fmt.Sprint(value)),
+				})
+			}
+		}
+		sd = append(sd, sde)
+	}
+	return sd, nil
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,159 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package otellog_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/rfc/v2/rfc5424"
+	"github.com/juju/rfc/v2/rfc5424/otellog"
+)
+
+type RecordSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&RecordSuite{})
+
+func newMessage() rfc5424.Message {
+	return rfc5424.Message{
+		Header: rfc5424.Header{
+			Priority: rfc5424.Priority{
+				Severity: rfc5424.SeverityWarning,
+				Facility: rfc5424.FacilityDaemon,
+			},
+			Timestamp: rfc5424.Timestamp{Time: time.Unix(54321, 123).UTC()},
+			Hostname:  rfc5424.Hostname{FQDN: "a.b.org"},
+			AppName:   "an-app",
+			ProcID:    "0119",
+			MsgID:     "xyz...",
+		},
+		StructuredData: rfc5424.StructuredData{
+			rfc5424.GenericStructuredDataElement{
+				SDID: "spam",
+				Data: []rfc5424.StructuredDataParam{
+					{Name: "x", Value: "y"},
+					{Name: "x", Value: "z"},
+				},
+			},
+			rfc5424.GenericStructuredDataElement{
+				SDID: "eggs@32473",
+				Data: []rfc5424.StructuredDataParam{
+					{Name: "a", Value: "b"},
+				},
+			},
+		},
+		Msg: "a message",
+	}
+}
+
+func newRecord() otellog.Record {
+	return otellog.Record{
+		Timestamp:      time.Unix(54321, 123).UTC(),
+		SeverityNumber: 13,
+		SeverityText:   "WARNING",
+		Body:           "a message",
+		Resource: map[string]interface{}{
+			"host.name":    "a.b.org",
+			"service.name": "an-app",
+		},
+		Attributes: map[string]interface{}{
+			"hostname": "a.b.org",
+			"appname":  "an-app",
+			"proc_id":  "0119",
+			"msg_id":   "xyz...",
+			"facility": int64(3),
+			"structured_data": map[string]interface{}{
+				"spam": map[string]interface{}{
+					"x": []interface{}{"y", "z"},
+				},
+				"eggs@32473": map[string]interface{}{
+					"a": "b",
+				},
+			},
+		},
+	}
+}
+
+func (s *RecordSuite) TestFromMessage(c *gc.C) {
+	rec := otellog.FromMessage(newMessage())
+
+	c.Check(rec, jc.DeepEquals, newRecord())
+}
+
+func (s *RecordSuite) TestFromMessageMinimal(c *gc.C) {
+	var msg rfc5424.Message
+	msg.Severity = rfc5424.SeverityNotice
+	msg.ProcID = "worker-1"
+
+	rec := otellog.FromMessage(msg)
+
+	c.Check(rec, jc.DeepEquals, otellog.Record{
+		SeverityNumber: 10,
+		SeverityText:   "NOTICE",
+		Resource:       map[string]interface{}{},
+		Attributes: map[string]interface{}{
+			"facility": int64(1),
+			"proc_id":  "worker-1",
+		},
+	})
+}
+
+func (s *RecordSuite) TestToMessage(c *gc.C) {
+	msg, err := otellog.ToMessage(newRecord())
+	c.Assert(err, jc.ErrorIsNil)
+
+	expected := newMessage()
+	// Elements are sorted by SD-ID.
+	expected.StructuredData[0], expected.StructuredData[1] = expected.StructuredData[1], expected.StructuredData[0]
+	c.Check(msg, jc.DeepEquals, expected)
+}
+
+func (s *RecordSuite) TestToMessageFromResource(c *gc.C) {
+	rec := newRecord()
+	delete(rec.Attributes, "hostname")
+	delete(rec.Attributes, "appname")
+	rec.Attributes["facility"] = "daemon"
+
+	msg, err := otellog.ToMessage(rec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(msg.Hostname, jc.DeepEquals, rfc5424.Hostname{FQDN: "a.b.org"})
+	c.Check(msg.AppName, gc.Equals, rfc5424.AppName("an-app"))
+	c.Check(msg.Facility, gc.Equals, rfc5424.FacilityDaemon)
+}
+
+func (s *RecordSuite) TestToMessageSeverityNumber(c *gc.C) {
+	msg, err := otellog.ToMessage(otellog.Record{
+		SeverityNumber: 18,
+		SeverityText:   "ERROR2",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(msg.Severity, gc.Equals, rfc5424.SeverityCrit)
+}
+
+func (s *RecordSuite) TestToMessageInvalid(c *gc.C) {
+	rec := newRecord()
+	rec.Attributes["facility"] = int64(99)
+
+	_, err := otellog.ToMessage(rec)
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `facility attribute \(unknown facility 99\) not valid`)
+}
+
+func (s *RecordSuite) TestToMessageBadStructuredData(c *gc.C) {
+	rec := newRecord()
+	rec.Attributes["structured_data"] = "[spam x=\"y\"]"
+
+	_, err := otellog.ToMessage(rec)
+
+	c.Check(err, gc.ErrorMatches, `structured_data attribute \(expected map, got string\) not valid`)
+}
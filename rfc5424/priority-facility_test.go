@@ -4,11 +4,14 @@
 package rfc5424_test
 
 import (
+	"flag"
 	"fmt"
+	"io"
 
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
 
 	"github.com/juju/rfc/v2/rfc5424"
 )
@@ -22,6 +25,79 @@ var _ = gc.Suite(&FacilitySuite{})
 func (s *FacilitySuite) TestString(c *gc.C) {
 }
 
+func (s *FacilitySuite) TestParseRoundTrip(c *gc.C) {
+	for fac := rfc5424.FacilityKern; fac <= rfc5424.FacilityLocal7; fac++ {
+		c.Logf("trying %s", fac)
+		text, err := fac.MarshalText()
+		c.Assert(err, jc.ErrorIsNil)
+
+		parsed, err := rfc5424.ParseFacility(string(text))
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(parsed, gc.Equals, fac)
+
+		parsed, err = rfc5424.ParseFacility(fac.String())
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(parsed, gc.Equals, fac)
+	}
+}
+
+func (s *FacilitySuite) TestParseAliases(c *gc.C) {
+	for name, expected := range map[string]rfc5424.Facility{
+		"kernel":   rfc5424.FacilityKern,
+		"security": rfc5424.FacilityAuth,
+		"LOCAL3":   rfc5424.FacilityLocal3,
+		"logaudit": rfc5424.FacilityLogAudit,
+		"logalert": rfc5424.FacilityLogAlert,
+		"cron2":    rfc5424.FacilityCron2,
+	} {
+		c.Logf("trying %q", name)
+
+		fac, err := rfc5424.ParseFacility(name)
+
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(fac, gc.Equals, expected)
+	}
+}
+
+func (s *FacilitySuite) TestParseUnknown(c *gc.C) {
+	_, err := rfc5424.ParseFacility("local8")
+
+	c.Check(err, gc.ErrorMatches, `unknown facility "local8"`)
+}
+
+func (s *FacilitySuite) TestMarshalTextZeroValue(c *gc.C) {
+	var fac rfc5424.Facility
+
+	text, err := fac.MarshalText()
+
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(string(text), gc.Equals, "user")
+}
+
+func (s *FacilitySuite) TestYAML(c *gc.C) {
+	var config struct {
+		Facility rfc5424.Facility `yaml:"facility"`
+	}
+	err := yaml.Unmarshal([]byte("facility: LOCAL3\n"), &config)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(config.Facility, gc.Equals, rfc5424.FacilityLocal3)
+
+	data, err := yaml.Marshal(config)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "facility: local3\n")
+}
+
+func (s *FacilitySuite) TestFlag(c *gc.C) {
+	var fac rfc5424.Facility
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.Var(&fac, "facility", "syslog facility")
+
+	err := flags.Parse([]string{"-facility", "daemon"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fac, gc.Equals, rfc5424.FacilityDaemon)
+}
+
 func (s *FacilitySuite) TestValidateSupported(c *gc.C) {
 	facilities := []rfc5424.Facility{
 		rfc5424.FacilityKern,
@@ -37,6 +113,9 @@ func (s *FacilitySuite) TestValidateSupported(c *gc.C) {
 		rfc5424.FacilityAuthpriv,
 		rfc5424.FacilityFTP,
 		rfc5424.FacilityNTP,
+		rfc5424.FacilityLogAudit,
+		rfc5424.FacilityLogAlert,
+		rfc5424.FacilityCron2,
 		rfc5424.FacilityLocal0,
 		rfc5424.FacilityLocal1,
 		rfc5424.FacilityLocal2,
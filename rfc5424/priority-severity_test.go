@@ -4,11 +4,14 @@
 package rfc5424_test
 
 import (
+	"flag"
 	"fmt"
+	"io"
 
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
 
 	"github.com/juju/rfc/v2/rfc5424"
 )
@@ -22,6 +25,83 @@ var _ = gc.Suite(&SeveritySuite{})
 func (s *SeveritySuite) TestString(c *gc.C) {
 }
 
+func (s *SeveritySuite) TestParseRoundTrip(c *gc.C) {
+	for sev := rfc5424.SeverityEmergency; sev <= rfc5424.SeverityDebug; sev++ {
+		c.Logf("trying %s", sev)
+		text, err := sev.MarshalText()
+		c.Assert(err, jc.ErrorIsNil)
+
+		parsed, err := rfc5424.ParseSeverity(string(text))
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(parsed, gc.Equals, sev)
+
+		parsed, err = rfc5424.ParseSeverity(sev.String())
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(parsed, gc.Equals, sev)
+	}
+}
+
+func (s *SeveritySuite) TestParseAliases(c *gc.C) {
+	for name, expected := range map[string]rfc5424.Severity{
+		"panic":   rfc5424.SeverityEmergency,
+		"Crit":    rfc5424.SeverityCrit,
+		"err":     rfc5424.SeverityError,
+		"error":   rfc5424.SeverityError,
+		"WARN":    rfc5424.SeverityWarning,
+		"warning": rfc5424.SeverityWarning,
+		"info":    rfc5424.SeverityInformational,
+	} {
+		c.Logf("trying %q", name)
+
+		sev, err := rfc5424.ParseSeverity(name)
+
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(sev, gc.Equals, expected)
+	}
+}
+
+func (s *SeveritySuite) TestParseUnknown(c *gc.C) {
+	_, err := rfc5424.ParseSeverity("Loud")
+
+	c.Check(err, gc.ErrorMatches, `unknown severity "Loud"`)
+}
+
+func (s *SeveritySuite) TestMarshalTextUnsupported(c *gc.C) {
+	_, err := rfc5424.Severity(8).MarshalText()
+
+	c.Check(err, gc.ErrorMatches, `severity 8 not recognized`)
+}
+
+func (s *SeveritySuite) TestYAML(c *gc.C) {
+	var config struct {
+		Level rfc5424.Severity `yaml:"level"`
+	}
+	err := yaml.Unmarshal([]byte("level: warn\n"), &config)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(config.Level, gc.Equals, rfc5424.SeverityWarning)
+
+	data, err := yaml.Marshal(config)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "level: warning\n")
+
+	err = yaml.Unmarshal([]byte("level: loud\n"), &config)
+	c.Check(err, gc.ErrorMatches, `unknown severity "loud"`)
+}
+
+func (s *SeveritySuite) TestFlag(c *gc.C) {
+	sev := rfc5424.SeverityInformational
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.Var(&sev, "level", "minimum severity")
+
+	err := flags.Parse([]string{"-level", "debug"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(sev, gc.Equals, rfc5424.SeverityDebug)
+
+	err = flags.Parse([]string{"-level", "loud"})
+	c.Check(err, gc.ErrorMatches, `invalid value "loud" for flag -level: unknown severity "loud"`)
+}
+
 func (s *SeveritySuite) TestValidateSupported(c *gc.C) {
 	severities := []rfc5424.Severity{
 		rfc5424.SeverityEmergency,
@@ -5,6 +5,7 @@ package rfc5424
 
 import (
 	"fmt"
+	"strings"
 )
 
 // These are the supported logging severity levels.
@@ -38,10 +39,9 @@ const (
 	FacilityAuthpriv
 	FacilityFTP
 	FacilityNTP
-
-	facilityLogAudit
-	facilityLogAlert
-	facilityCron2
+	FacilityLogAudit
+	FacilityLogAlert
+	FacilityCron2
 
 	FacilityLocal0
 	FacilityLocal1
@@ -150,6 +150,85 @@ func (s Severity) Validate() error {
 	return nil
 }
 
+// severityNames holds the name of each severity used by MarshalText.
+// These are the names used by syslog.conf and rsyslog.
+var severityNames = map[Severity]string{
+	SeverityEmergency:     "emerg",
+	SeverityAlert:         "alert",
+	SeverityCrit:          "crit",
+	SeverityError:         "err",
+	SeverityWarning:       "warning",
+	SeverityNotice:        "notice",
+	SeverityInformational: "info",
+	SeverityDebug:         "debug",
+}
+
+// severityAliases holds the other names that ParseSeverity accepts.
+var severityAliases = map[string]Severity{
+	"emergency":     SeverityEmergency,
+	"panic":         SeverityEmergency,
+	"critical":      SeverityCrit,
+	"error":         SeverityError,
+	"warn":          SeverityWarning,
+	"informational": SeverityInformational,
+}
+
+// ParseSeverity returns the severity with the given name, ignoring
+// case. It accepts the names returned by String and MarshalText, as
+// well as the common aliases "emergency", "panic", "critical", "error",
+// "warn" and "informational".
+func ParseSeverity(name string) (Severity, error) {
+	lower := strings.ToLower(name)
+	for sev, sevName := range severityNames {
+		if lower == sevName || lower == strings.ToLower(sev.String()) {
+			return sev, nil
+		}
+	}
+	if sev, ok := severityAliases[lower]; ok {
+		return sev, nil
+	}
+	return 0, fmt.Errorf("unknown severity %q", name)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Severity) MarshalText() ([]byte, error) {
+	name, ok := severityNames[s]
+	if !ok {
+		return nil, fmt.Errorf("severity %d not recognized", s)
+	}
+	return []byte(name), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *Severity) UnmarshalText(text []byte) error {
+	sev, err := ParseSeverity(string(text))
+	if err != nil {
+		return err
+	}
+	*s = sev
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (s Severity) MarshalYAML() (interface{}, error) {
+	text, err := s.MarshalText()
+	return string(text), err
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (s *Severity) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var name string
+	if err := unmarshal(&name); err != nil {
+		return err
+	}
+	return s.UnmarshalText([]byte(name))
+}
+
+// Set implements flag.Value.
+func (s *Severity) Set(name string) error {
+	return s.UnmarshalText([]byte(name))
+}
+
 // Facility is the system component for which the log record
 // was created.
 type Facility int
@@ -184,6 +263,14 @@ func (f Facility) String() string {
 		return "AUTHPRIV"
 	case FacilityFTP:
 		return "FTP"
+	case FacilityNTP:
+		return "NTP"
+	case FacilityLogAudit:
+		return "AUDIT"
+	case FacilityLogAlert:
+		return "ALERT"
+	case FacilityCron2:
+		return "CLOCK"
 	case FacilityLocal0:
 		return "LOCAL0"
 	case FacilityLocal1:
@@ -226,3 +313,101 @@ func (f Facility) Validate() error {
 	}
 	return nil
 }
+
+// facilityNames holds the name of each facility used by MarshalText.
+// These are the names used by rsyslog.
+var facilityNames = map[Facility]string{
+	FacilityKern:     "kern",
+	FacilityUser:     "user",
+	FacilityMail:     "mail",
+	FacilityDaemon:   "daemon",
+	FacilityAuth:     "auth",
+	FacilitySyslog:   "syslog",
+	FacilityLPR:      "lpr",
+	FacilityNews:     "news",
+	FacilityUUCP:     "uucp",
+	FacilityCron:     "cron",
+	FacilityAuthpriv: "authpriv",
+	FacilityFTP:      "ftp",
+	FacilityNTP:      "ntp",
+	FacilityLogAudit: "audit",
+	FacilityLogAlert: "alert",
+	FacilityCron2:    "clock",
+	FacilityLocal0:   "local0",
+	FacilityLocal1:   "local1",
+	FacilityLocal2:   "local2",
+	FacilityLocal3:   "local3",
+	FacilityLocal4:   "local4",
+	FacilityLocal5:   "local5",
+	FacilityLocal6:   "local6",
+	FacilityLocal7:   "local7",
+}
+
+// facilityAliases holds the other names that ParseFacility accepts.
+var facilityAliases = map[string]Facility{
+	"kernel":   FacilityKern,
+	"security": FacilityAuth,
+	"logaudit": FacilityLogAudit,
+	"logalert": FacilityLogAlert,
+	"cron2":    FacilityCron2,
+}
+
+// ParseFacility returns the facility with the given name, ignoring
+// case. It accepts the names returned by String and MarshalText, as
+// well as the common aliases "kernel", "security" (for auth),
+// "logaudit", "logalert" and "cron2".
+func ParseFacility(name string) (Facility, error) {
+	lower := strings.ToLower(name)
+	for fac, facName := range facilityNames {
+		if lower == facName || lower == strings.ToLower(fac.String()) {
+			return fac, nil
+		}
+	}
+	if fac, ok := facilityAliases[lower]; ok {
+		return fac, nil
+	}
+	return 0, fmt.Errorf("unknown facility %q", name)
+}
+
+// MarshalText implements encoding.TextMarshaler. The default facility
+// is given as "user".
+func (f Facility) MarshalText() ([]byte, error) {
+	if f == facilityDefault {
+		f = FacilityUser
+	}
+	name, ok := facilityNames[f]
+	if !ok {
+		return nil, fmt.Errorf("facility %d not recognized", f)
+	}
+	return []byte(name), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (f *Facility) UnmarshalText(text []byte) error {
+	fac, err := ParseFacility(string(text))
+	if err != nil {
+		return err
+	}
+	*f = fac
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (f Facility) MarshalYAML() (interface{}, error) {
+	text, err := f.MarshalText()
+	return string(text), err
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (f *Facility) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var name string
+	if err := unmarshal(&name); err != nil {
+		return err
+	}
+	return f.UnmarshalText([]byte(name))
+}
+
+// Set implements flag.Value.
+func (f *Facility) Set(name string) error {
+	return f.UnmarshalText([]byte(name))
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package rfc5424
+
+import "reflect"
+
+// RawMessage holds a parsed message along with the text it was parsed
+// from. Parsing and then calling String normalizes a message, e.g. its
+// timestamp format and the escaping in its structured data, so a relay
+// that must not alter what it forwards uses the raw text instead. Each
+// text field holds exactly what was received, including any NILVALUE
+// ("-").
+type RawMessage struct {
+	// Message is the parsed message. Structured data elements may be
+	// appended to it without losing the original text; see Verbatim.
+	Message Message
+
+	// Text is the complete message.
+	Text string
+
+	// Priority is the PRI part, including the angle brackets.
+	Priority string
+
+	// Version is the VERSION field.
+	Version string
+
+	// Timestamp is the TIMESTAMP field.
+	Timestamp string
+
+	// Hostname is the HOSTNAME field.
+	Hostname string
+
+	// AppName is the APP-NAME field.
+	AppName string
+
+	// ProcID is the PROCID field.
+	ProcID string
+
+	// MsgID is the MSGID field.
+	MsgID string
+
+	// StructuredData is the STRUCTURED-DATA part, including the
+	// brackets around each element.
+	StructuredData string
+
+	// Msg is the MSG part, including any BOM. It does not include the
+	// space that separates it from the structured data.
+	Msg string
+
+	// These hold the parsed message, for checking whether it has
+	// been changed since.
+	header  Header
+	numSD   int
+	sdStart int
+	sd      string
+	msg     string
+}
+
+// ParseRawMessage parses the message as ParseMessage does, and keeps
+// the text it was parsed from so that it can be forwarded exactly as
+// it was received (see Verbatim and RawSender).
+func ParseRawMessage(str string) (*RawMessage, error) {
+	raw := &RawMessage{Text: str}
+	m, sdStart, err := parseMessage(str, raw)
+	if err != nil {
+		return nil, err
+	}
+	raw.Message = m
+	raw.header = m.Header
+	raw.sdStart = sdStart
+	raw.numSD = len(m.StructuredData)
+	raw.sd = m.StructuredData.String()
+	raw.msg = m.Msg
+	return raw, nil
+}
+
+// Verbatim returns the text the message was parsed from, as long as
+// Message has not been changed since, except for structured data
+// elements appended to it. Any such elements are added to the end of
+// the original structured data, as a relay may do (see RFC 5424
+// section 4.3), so that the rest of the text, including anything
+// covered by an RFC 5848 signature, is left untouched. If the message
+// has been changed, false is returned.
+func (raw *RawMessage) Verbatim() (string, bool) {
+	m := raw.Message
+	if len(m.StructuredData) < raw.numSD || m.Msg != raw.msg {
+		return "", false
+	}
+	if m.StructuredData[:raw.numSD].String() != raw.sd {
+		return "", false
+	}
+	if !reflect.DeepEqual(m.Header, raw.header) {
+		return "", false
+	}
+
+	added := m.StructuredData[raw.numSD:]
+	if len(added) == 0 {
+		return raw.Text, true
+	}
+	sd := raw.StructuredData
+	if sd == "-" {
+		sd = ""
+	}
+	end := raw.sdStart + len(raw.StructuredData)
+	text := raw.Text[:raw.sdStart] + sd + added.String() + raw.Text[end:]
+	return text, true
+}
+
+// String returns the message as it was received if possible (see
+// Verbatim), and otherwise the RFC 5424 representation of Message.
+func (raw *RawMessage) String() string {
+	if str, ok := raw.Verbatim(); ok {
+		return str
+	}
+	return raw.Message.String()
+}
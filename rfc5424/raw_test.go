@@ -0,0 +1,130 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package rfc5424_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/rfc/v2/rfc5424"
+)
+
+type RawMessageSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&RawMessageSuite{})
+
+// rawText is not in the form that Message.String produces: the
+// timestamp has an offset, the MSG has a BOM and the param value has
+// an escaped character that does not need it.
+const rawText = "<165>1 2003-10-11T22:14:15.003+02:00 mymachine.example.com evntslog - ID47 [exampleSDID@32473 iut=\"3\" eventSource=\"App\\lication\"] \xef\xbb\xbfAn application event"
+
+var addedElement = rfc5424.GenericStructuredDataElement{
+	SDID: "origin",
+	Data: []rfc5424.StructuredDataParam{{Name: "ip", Value: "10.0.0.1"}},
+}
+
+func (s *RawMessageSuite) TestParseFields(c *gc.C) {
+	raw, err := rfc5424.ParseRawMessage(rawText)
+	c.Assert(err, jc.ErrorIsNil)
+
+	msg, err := rfc5424.ParseMessage(rawText)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(raw.Message, jc.DeepEquals, msg)
+	c.Check(raw.Text, gc.Equals, rawText)
+	c.Check(raw.Priority, gc.Equals, "<165>")
+	c.Check(raw.Version, gc.Equals, "1")
+	c.Check(raw.Timestamp, gc.Equals, "2003-10-11T22:14:15.003+02:00")
+	c.Check(raw.Hostname, gc.Equals, "mymachine.example.com")
+	c.Check(raw.AppName, gc.Equals, "evntslog")
+	c.Check(raw.ProcID, gc.Equals, "-")
+	c.Check(raw.MsgID, gc.Equals, "ID47")
+	c.Check(raw.StructuredData, gc.Equals, `[exampleSDID@32473 iut="3" eventSource="App\lication"]`)
+	c.Check(raw.Msg, gc.Equals, "\xef\xbb\xbfAn application event")
+	c.Check(msg.String(), gc.Not(gc.Equals), rawText)
+}
+
+func (s *RawMessageSuite) TestParseError(c *gc.C) {
+	raw, err := rfc5424.ParseRawMessage("<8>1 - - - - - - \xff")
+
+	c.Check(err, gc.ErrorMatches, "bad Msg: invalid UTF-8")
+	c.Check(raw, gc.IsNil)
+}
+
+func (s *RawMessageSuite) TestVerbatimUnchanged(c *gc.C) {
+	raw, err := rfc5424.ParseRawMessage(rawText)
+	c.Assert(err, jc.ErrorIsNil)
+
+	str, ok := raw.Verbatim()
+
+	c.Check(ok, jc.IsTrue)
+	c.Check(str, gc.Equals, rawText)
+	c.Check(raw.String(), gc.Equals, rawText)
+}
+
+func (s *RawMessageSuite) TestVerbatimAppended(c *gc.C) {
+	raw, err := rfc5424.ParseRawMessage(rawText)
+	c.Assert(err, jc.ErrorIsNil)
+	raw.Message.StructuredData = append(raw.Message.StructuredData, addedElement)
+
+	str, ok := raw.Verbatim()
+
+	c.Check(ok, jc.IsTrue)
+	c.Check(str, gc.Equals, "<165>1 2003-10-11T22:14:15.003+02:00 mymachine.example.com evntslog - ID47 [exampleSDID@32473 iut=\"3\" eventSource=\"App\\lication\"][origin ip=\"10.0.0.1\"] \xef\xbb\xbfAn application event")
+}
+
+func (s *RawMessageSuite) TestVerbatimAppendedToNil(c *gc.C) {
+	raw, err := rfc5424.ParseRawMessage("<8>1 - - - - - -")
+	c.Assert(err, jc.ErrorIsNil)
+	raw.Message.StructuredData = append(raw.Message.StructuredData, addedElement)
+
+	str, ok := raw.Verbatim()
+
+	c.Check(ok, jc.IsTrue)
+	c.Check(str, gc.Equals, `<8>1 - - - - - [origin ip="10.0.0.1"]`)
+}
+
+func (s *RawMessageSuite) TestVerbatimChanged(c *gc.C) {
+	for i, change := range []func(*rfc5424.Message){
+		func(msg *rfc5424.Message) { msg.Severity = rfc5424.SeverityDebug },
+		func(msg *rfc5424.Message) { msg.Hostname = rfc5424.Hostname{FQDN: "other.example.com"} },
+		func(msg *rfc5424.Message) { msg.MsgID = "ID48" },
+		func(msg *rfc5424.Message) { msg.Msg = "changed" },
+		func(msg *rfc5424.Message) { msg.StructuredData = nil },
+		func(msg *rfc5424.Message) {
+			msg.StructuredData = rfc5424.StructuredData{addedElement}
+		},
+	} {
+		c.Logf("trying #%d", i)
+		raw, err := rfc5424.ParseRawMessage(rawText)
+		c.Assert(err, jc.ErrorIsNil)
+		change(&raw.Message)
+
+		_, ok := raw.Verbatim()
+
+		c.Check(ok, jc.IsFalse)
+		c.Check(raw.String(), gc.Equals, raw.Message.String())
+	}
+}
+
+func (s *RawMessageSuite) TestSendRaw(c *gc.C) {
+	raw, err := rfc5424.ParseRawMessage(rawText)
+	c.Assert(err, jc.ErrorIsNil)
+	var sender messageSender
+
+	err = rfc5424.SendRaw(&sender, raw)
+
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(sender, jc.DeepEquals, messageSender{raw.Message})
+}
+
+// messageSender is a Sender that is not a RawSender.
+type messageSender []rfc5424.Message
+
+func (s *messageSender) Send(msg rfc5424.Message) error {
+	*s = append(*s, msg)
+	return nil
+}
@@ -0,0 +1,25 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+// The relay package forwards syslog messages received by a server to
+// one or more upstream collectors, as an RFC 5424 relay.
+//
+// A relay that adds an origin element to each message it forwards
+// might look like this:
+//
+//	origin, err := sdelements.BuildInfoOrigin(enterpriseID)
+//	origin.IPs = []net.IP{relayIP}
+//	r, err := relay.New(relay.Config{
+//		Upstreams:         []rfc5424.Sender{client},
+//		AddStructuredData: rfc5424.StructuredData{origin},
+//	})
+//	srv, err := server.New(server.Config{Handler: r})
+//
+// Messages received by a server keep their original text, so when an
+// upstream is an rfc5424.RawSender, such as an *rfc5424.Client, they
+// are forwarded exactly as they were received, apart from any added
+// structured data (see rfc5424.RawMessage.Verbatim). A Transform that
+// changes a message makes it be sent in its normalized form instead.
+//
+// See https://tools.ietf.org/html/rfc5424#section-4.3.
+package relay
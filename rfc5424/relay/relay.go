@@ -0,0 +1,122 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package relay
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/rfc/v2/rfc5424"
+	"github.com/juju/rfc/v2/rfc5424/server"
+)
+
+// Config is the configuration for a relay.
+type Config struct {
+	// Upstreams are where messages are forwarded to, typically
+	// *rfc5424.Client values. Every message is sent to each of them.
+	Upstreams []rfc5424.Sender
+
+	// Filter, if set, selects the received messages that are
+	// forwarded, e.g. a filter from the filter package.
+	Filter rfc5424.Matcher
+
+	// Transform, if set, is called for each message that passes the
+	// filter, and its result is forwarded. RFC 5424 does not allow a
+	// relay to change messages other than by adding structured data,
+	// so this is for deployments that knowingly depart from that.
+	Transform func(rfc5424.Message) rfc5424.Message
+
+	// AddStructuredData holds elements, such as an origin element,
+	// that are added to each forwarded message. An element is not
+	// added to a message that already has one with the same SD-ID,
+	// since RFC 5424 allows each SD-ID only once per message.
+	AddStructuredData rfc5424.StructuredData
+
+	// ErrorHandler, if set, is called when forwarding a message to an
+	// upstream fails, with the index of the upstream in Upstreams. It
+	// may be called concurrently.
+	ErrorHandler func(upstream int, msg rfc5424.Message, err error)
+}
+
+// Validate ensures that the config is correct.
+func (cfg Config) Validate() error {
+	if len(cfg.Upstreams) == 0 {
+		return errors.NotValidf("no Upstreams")
+	}
+	for i, upstream := range cfg.Upstreams {
+		if upstream == nil {
+			return errors.NotValidf("nil Upstreams[%d]", i)
+		}
+	}
+	if err := cfg.AddStructuredData.Validate(); err != nil {
+		return errors.NotValidf("AddStructuredData (%v)", err)
+	}
+	return nil
+}
+
+// Relay is a server.Handler that forwards the messages it receives.
+// The message is otherwise forwarded as received; in particular its
+// timestamp and hostname are those of the original sender.
+type Relay struct {
+	cfg Config
+
+	// added holds just the structured data to add.
+	added rfc5424.MessageTemplate
+
+	// locks serialise the sends to each upstream, since the handler
+	// is called concurrently and a sender need not be safe for
+	// concurrent use. Each upstream has its own lock so that a slow
+	// one does not hold up the others.
+	locks []sync.Mutex
+}
+
+var _ server.Handler = (*Relay)(nil)
+
+// New returns a new relay.
+func New(cfg Config) (*Relay, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	r := &Relay{
+		cfg:   cfg,
+		added: rfc5424.MessageTemplate{StructuredData: cfg.AddStructuredData},
+		locks: make([]sync.Mutex, len(cfg.Upstreams)),
+	}
+	return r, nil
+}
+
+// HandleSyslog implements server.Handler.
+func (r *Relay) HandleSyslog(received server.Message) {
+	if r.cfg.Filter != nil && !r.cfg.Filter.Match(received.Message) {
+		return
+	}
+	msg := received.Message
+	if r.cfg.Transform != nil {
+		msg = r.cfg.Transform(msg)
+	}
+	send := func(upstream rfc5424.Sender) error {
+		return upstream.Send(msg)
+	}
+	if received.Raw != nil {
+		raw := *received.Raw
+		raw.Message = msg
+		applied := r.added.ApplyRaw(&raw)
+		msg = applied.Message
+		send = func(upstream rfc5424.Sender) error {
+			return rfc5424.SendRaw(upstream, applied)
+		}
+	} else {
+		msg = r.added.Apply(msg)
+	}
+
+	for i, upstream := range r.cfg.Upstreams {
+		r.locks[i].Lock()
+		err := send(upstream)
+		r.locks[i].Unlock()
+		if err != nil && r.cfg.ErrorHandler != nil {
+			r.cfg.ErrorHandler(i, msg, errors.Trace(err))
+		}
+	}
+}
@@ -0,0 +1,273 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package relay_test
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/rfc/v2/rfc5424"
+	"github.com/juju/rfc/v2/rfc5424/relay"
+	"github.com/juju/rfc/v2/rfc5424/rfc5424test"
+	"github.com/juju/rfc/v2/rfc5424/sdelements"
+	"github.com/juju/rfc/v2/rfc5424/server"
+)
+
+type RelaySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&RelaySuite{})
+
+func newMessage() rfc5424.Message {
+	return rfc5424.Message{
+		Header: rfc5424.Header{
+			Priority: rfc5424.Priority{
+				Severity: rfc5424.SeverityWarning,
+				Facility: rfc5424.FacilityDaemon,
+			},
+			Timestamp: rfc5424.Timestamp{Time: time.Unix(54321, 123).UTC()},
+			Hostname:  rfc5424.Hostname{FQDN: "a.b.org"},
+			AppName:   "an-app",
+			ProcID:    "119",
+			MsgID:     "xyz...",
+		},
+		StructuredData: rfc5424.StructuredData{
+			rfc5424.GenericStructuredDataElement{
+				SDID: "spam",
+				Data: []rfc5424.StructuredDataParam{{Name: "x", Value: "y"}},
+			},
+		},
+		Msg: "a message",
+	}
+}
+
+func (s *RelaySuite) TestValidate(c *gc.C) {
+	_, err := relay.New(relay.Config{})
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, "no Upstreams not valid")
+
+	_, err = relay.New(relay.Config{Upstreams: []rfc5424.Sender{nil}})
+	c.Check(err, gc.ErrorMatches, `nil Upstreams\[0\] not valid`)
+}
+
+func (s *RelaySuite) TestForward(c *gc.C) {
+	var first, second recordingSender
+	origin := sdelements.Origin{
+		IPs:          []net.IP{net.ParseIP("10.0.0.1")},
+		EnterpriseID: sdelements.OriginEnterpriseID{Number: 32473},
+		SoftwareName: "relay",
+	}
+	r, err := relay.New(relay.Config{
+		Upstreams:         []rfc5424.Sender{&first, &second},
+		AddStructuredData: rfc5424.StructuredData{origin},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	r.HandleSyslog(server.Message{Message: newMessage()})
+
+	expected := newMessage()
+	expected.StructuredData = append(expected.StructuredData, origin)
+	c.Check(first.messages, jc.DeepEquals, []rfc5424.Message{expected})
+	c.Check(second.messages, jc.DeepEquals, []rfc5424.Message{expected})
+}
+
+func (s *RelaySuite) TestForwardVerbatim(c *gc.C) {
+	var upstream rawSender
+	origin := sdelements.Origin{
+		EnterpriseID: sdelements.OriginEnterpriseID{Number: 32473},
+		SoftwareName: "relay",
+	}
+	r, err := relay.New(relay.Config{
+		Upstreams:         []rfc5424.Sender{&upstream},
+		AddStructuredData: rfc5424.StructuredData{origin},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	raw, err := rfc5424.ParseRawMessage(`<28>1 1970-01-01T16:05:21+01:00 a.b.org an-app 119 - [spam x="\y"] a message`)
+	c.Assert(err, jc.ErrorIsNil)
+
+	r.HandleSyslog(server.Message{Message: raw.Message, Raw: raw})
+
+	c.Check(upstream.messages, gc.HasLen, 0)
+	c.Assert(upstream.texts, gc.HasLen, 1)
+	c.Check(upstream.texts[0], gc.Equals, `<28>1 1970-01-01T16:05:21+01:00 a.b.org an-app 119 - [spam x="\y"][origin enterpriseID="32473" software="relay"] a message`)
+}
+
+func (s *RelaySuite) TestExistingElementNotAdded(c *gc.C) {
+	var upstream recordingSender
+	r, err := relay.New(relay.Config{
+		Upstreams: []rfc5424.Sender{&upstream},
+		AddStructuredData: rfc5424.StructuredData{
+			rfc5424.GenericStructuredDataElement{SDID: "spam"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	r.HandleSyslog(server.Message{Message: newMessage()})
+
+	c.Check(upstream.messages, jc.DeepEquals, []rfc5424.Message{newMessage()})
+}
+
+func (s *RelaySuite) TestFilterAndTransform(c *gc.C) {
+	var upstream recordingSender
+	r, err := relay.New(relay.Config{
+		Upstreams: []rfc5424.Sender{&upstream},
+		Filter: rfc5424.MatcherFunc(func(msg rfc5424.Message) bool {
+			return msg.Severity <= rfc5424.SeverityWarning
+		}),
+		Transform: func(msg rfc5424.Message) rfc5424.Message {
+			msg.Msg = "[relayed] " + msg.Msg
+			return msg
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	debug := newMessage()
+	debug.Severity = rfc5424.SeverityDebug
+	r.HandleSyslog(server.Message{Message: debug})
+	r.HandleSyslog(server.Message{Message: newMessage()})
+
+	c.Assert(upstream.messages, gc.HasLen, 1)
+	c.Check(upstream.messages[0].Msg, gc.Equals, "[relayed] a message")
+}
+
+func (s *RelaySuite) TestUpstreamError(c *gc.C) {
+	failing := recordingSender{err: errors.New("boom")}
+	var working recordingSender
+	var failures []int
+	r, err := relay.New(relay.Config{
+		Upstreams: []rfc5424.Sender{&failing, &working},
+		ErrorHandler: func(upstream int, msg rfc5424.Message, err error) {
+			c.Check(msg.Msg, gc.Equals, "a message")
+			c.Check(err, gc.ErrorMatches, "boom")
+			failures = append(failures, upstream)
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	r.HandleSyslog(server.Message{Message: newMessage()})
+
+	c.Check(failures, jc.DeepEquals, []int{0})
+	c.Check(working.messages, gc.HasLen, 1)
+}
+
+func (s *RelaySuite) TestSlowUpstream(c *gc.C) {
+	slow := &blockingSender{
+		entered: make(chan struct{}, 2),
+		release: make(chan struct{}),
+	}
+	fast := &countingSender{}
+	r, err := relay.New(relay.Config{
+		Upstreams: []rfc5424.Sender{fast, slow},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer close(slow.release)
+
+	for i := 0; i < 2; i++ {
+		go r.HandleSyslog(server.Message{Message: newMessage()})
+	}
+	<-slow.entered
+
+	// The second message reaches the fast upstream while the slow
+	// one is still sending the first.
+	deadline := time.Now().Add(10 * time.Second)
+	for fast.count() < 2 {
+		if time.Now().After(deadline) {
+			c.Fatalf("fast upstream got %d messages, expected 2", fast.count())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func (s *RelaySuite) TestEndToEnd(c *gc.C) {
+	recorder := rfc5424test.NewRecorder()
+	collector := rfc5424test.NewServer(recorder)
+	collector.Start()
+	defer collector.Close()
+	upstream, err := rfc5424.Open(collector.Addr().String(), rfc5424.ClientConfig{}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	defer upstream.Close()
+
+	r, err := relay.New(relay.Config{Upstreams: []rfc5424.Sender{upstream}})
+	c.Assert(err, jc.ErrorIsNil)
+	srv, err := server.New(server.Config{Handler: r})
+	c.Assert(err, jc.ErrorIsNil)
+	defer srv.Close()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	go srv.Serve(l)
+
+	client, err := rfc5424.Open(l.Addr().String(), rfc5424.ClientConfig{}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	defer client.Close()
+	err = client.Send(newMessage())
+	c.Assert(err, jc.ErrorIsNil)
+
+	received, err := recorder.WaitForN(1, 10*time.Second)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(received[0].Message, gc.Equals, newMessage().String())
+	c.Check(received[0].Parsed.Timestamp, jc.DeepEquals, newMessage().Timestamp)
+}
+
+type recordingSender struct {
+	messages []rfc5424.Message
+	err      error
+}
+
+func (s *recordingSender) Send(msg rfc5424.Message) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.messages = append(s.messages, msg)
+	return nil
+}
+
+// rawSender records the text of the messages sent with SendRaw.
+type rawSender struct {
+	recordingSender
+	texts []string
+}
+
+func (s *rawSender) SendRaw(raw *rfc5424.RawMessage) error {
+	s.texts = append(s.texts, raw.String())
+	return nil
+}
+
+// blockingSender blocks in Send until release is closed.
+type blockingSender struct {
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingSender) Send(rfc5424.Message) error {
+	s.entered <- struct{}{}
+	<-s.release
+	return nil
+}
+
+// countingSender counts the messages sent to it. It is safe for
+// concurrent use.
+type countingSender struct {
+	mu sync.Mutex
+	n  int
+}
+
+func (s *countingSender) Send(rfc5424.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.n++
+	return nil
+}
+
+func (s *countingSender) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.n
+}
@@ -0,0 +1,456 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package relp
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/rfc/v2/rfc5424"
+)
+
+// DefaultWindow is the window used when ClientConfig.Window is not
+// set.
+const DefaultWindow = 128
+
+// maxResponseSize limits the data of the server's responses, which
+// hold no more than a status and the session offers.
+const maxResponseSize = 64 * 1024
+
+// DialFunc is a function that may be used to open a network
+// connection. Unlike rfc5424.DialFunc the connection must be readable,
+// for the server's acknowledgements.
+type DialFunc func(network, address string) (net.Conn, error)
+
+// ClientConfig is the configuration for a RELP client.
+type ClientConfig struct {
+	// ClientConfig holds the settings shared with rfc5424.Client.
+	// Framing is ignored, as RELP has its own. SendTimeout limits
+	// each write and, when it is set, how long Send waits for room
+	// in the window and Close waits for acknowledgements.
+	rfc5424.ClientConfig
+
+	// Window is the number of messages that may be waiting for
+	// acknowledgement. Send blocks while the window is full. If not
+	// set, DefaultWindow is used.
+	Window int
+
+	// ErrorHandler, if set, is called for each message that the
+	// server rejects. Rejected messages are not sent again.
+	ErrorHandler func(error)
+}
+
+// Validate ensures that the config is correct.
+func (cfg ClientConfig) Validate() error {
+	if cfg.Window < 0 {
+		return errors.NotValidf("negative Window")
+	}
+	if cfg.SendTimeout < 0 {
+		return errors.NotValidf("negative SendTimeout")
+	}
+	return nil
+}
+
+// pending is a message waiting for acknowledgement.
+type pending struct {
+	data []byte
+}
+
+// session is a connection on which a RELP session was opened.
+type session struct {
+	conn   net.Conn
+	reader *FrameReader
+
+	// txnr is the last transaction number used.
+	txnr int
+
+	// inflight holds the messages sent in the session that have not
+	// been acknowledged, keyed by transaction number.
+	inflight map[int]*pending
+
+	// closeTxnr is the transaction number of the close command, once
+	// it has been sent, and closed is closed when it is acknowledged.
+	closeTxnr int
+	closed    chan struct{}
+
+	// dead is closed, and err set, when the session fails.
+	dead chan struct{}
+	once sync.Once
+	err  error
+}
+
+// nextTxnr returns the next transaction number of the session.
+func (sess *session) nextTxnr() int {
+	sess.txnr = sess.txnr%MaxTxnr + 1
+	return sess.txnr
+}
+
+func (sess *session) write(frame Frame, timeout time.Duration) error {
+	if timeout > 0 {
+		if err := sess.conn.SetWriteDeadline(time.Now().Add(timeout)); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	_, err := sess.conn.Write(frame.Bytes())
+	return errors.Trace(err)
+}
+
+// fail ends the session, unless it has already ended.
+func (sess *session) fail(err error) {
+	sess.once.Do(func() {
+		sess.err = err
+		close(sess.dead)
+		sess.conn.Close()
+	})
+}
+
+func (sess *session) isDead() bool {
+	select {
+	case <-sess.dead:
+		return true
+	default:
+		return false
+	}
+}
+
+// Client sends syslog messages to a RELP server. Unlike with a plain
+// TCP client, messages are kept until the server acknowledges them,
+// and if the connection fails, the next call to Send or Close
+// reconnects and sends the unacknowledged messages again, in order. A
+// message may therefore be received more than once, but none that
+// Send accepted is lost while the client is open. Client is safe for
+// concurrent use.
+type Client struct {
+	host string
+	cfg  ClientConfig
+	dial DialFunc
+
+	mu     sync.Mutex
+	closed bool
+	sess   *session
+
+	// unacked holds the messages waiting for acknowledgement, in
+	// the order they were sent.
+	unacked []*pending
+
+	// changed is closed, and replaced, whenever a message is
+	// acknowledged.
+	changed chan struct{}
+}
+
+var (
+	_ rfc5424.Sender    = (*Client)(nil)
+	_ rfc5424.RawSender = (*Client)(nil)
+)
+
+// Open opens a RELP session with the server at the given host address.
+// If no dial func is provided then net.Dial is used.
+func Open(host string, cfg ClientConfig, dial DialFunc) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if cfg.Window == 0 {
+		cfg.Window = DefaultWindow
+	}
+	if dial == nil {
+		dial = net.Dial
+	}
+	client := &Client{
+		host:    host,
+		cfg:     cfg,
+		dial:    dial,
+		changed: make(chan struct{}),
+	}
+	if err := client.connect(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return client, nil
+}
+
+// connect opens a new session and sends any unacknowledged messages
+// in it. It is called with the mutex held, except from Open.
+func (client *Client) connect() error {
+	conn, err := client.dial("tcp", client.host)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	sess := &session{
+		conn:     conn,
+		reader:   NewFrameReader(conn, maxResponseSize),
+		inflight: make(map[int]*pending),
+		closed:   make(chan struct{}),
+		dead:     make(chan struct{}),
+	}
+	if err := client.open(sess); err != nil {
+		conn.Close()
+		return errors.Annotate(err, "opening RELP session")
+	}
+	client.sess = sess
+	go client.readResponses(sess)
+
+	for _, p := range client.unacked {
+		if err := client.write(sess, p); err != nil {
+			sess.fail(err)
+			return errors.Annotate(err, "resending unacknowledged messages")
+		}
+	}
+	return nil
+}
+
+// open negotiates the session with the server.
+func (client *Client) open(sess *session) error {
+	txnr := sess.nextTxnr()
+	frame := Frame{Txnr: txnr, Command: CommandOpen, Data: offers(CommandSyslog)}
+	if err := sess.write(frame, client.cfg.SendTimeout); err != nil {
+		return errors.Trace(err)
+	}
+	if client.cfg.SendTimeout > 0 {
+		if err := sess.conn.SetReadDeadline(time.Now().Add(client.cfg.SendTimeout)); err != nil {
+			return errors.Trace(err)
+		}
+		defer sess.conn.SetReadDeadline(time.Time{})
+	}
+	reply, err := sess.reader.ReadFrame()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if reply.Command != CommandResponse || reply.Txnr != txnr {
+		return errors.Errorf("unexpected reply %d %s", reply.Txnr, reply.Command)
+	}
+	rsp, err := parseResponse(reply.Data)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !rsp.ok() {
+		return errors.Errorf("server refused: %03d %s", rsp.code, rsp.text)
+	}
+	if !offersCommand(parseOffers(rsp.data), CommandSyslog) {
+		return errors.New("server does not support the syslog command")
+	}
+	return nil
+}
+
+// write sends the message in the session.
+func (client *Client) write(sess *session, p *pending) error {
+	txnr := sess.nextTxnr()
+	sess.inflight[txnr] = p
+	frame := Frame{Txnr: txnr, Command: CommandSyslog, Data: p.data}
+	return errors.Trace(sess.write(frame, client.cfg.SendTimeout))
+}
+
+// readResponses handles the server's responses until the session
+// fails or is closed.
+func (client *Client) readResponses(sess *session) {
+	for {
+		frame, err := sess.reader.ReadFrame()
+		if err != nil {
+			sess.fail(err)
+			return
+		}
+		switch frame.Command {
+		case CommandResponse:
+			if err := client.acknowledge(sess, frame); err != nil {
+				sess.fail(err)
+				return
+			}
+		case CommandServerClose:
+			sess.fail(errors.New("server closed the session"))
+			return
+		default:
+			sess.fail(errors.Errorf("unexpected RELP command %q", frame.Command))
+			return
+		}
+	}
+}
+
+// acknowledge handles the server's response to a command.
+func (client *Client) acknowledge(sess *session, frame Frame) error {
+	rsp, err := parseResponse(frame.Data)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	client.mu.Lock()
+	if sess.closeTxnr != 0 && frame.Txnr == sess.closeTxnr {
+		// Clear it so that a repeated response is not taken for a
+		// second acknowledgement of the close.
+		sess.closeTxnr = 0
+		client.mu.Unlock()
+		close(sess.closed)
+		return nil
+	}
+	p, ok := sess.inflight[frame.Txnr]
+	if !ok {
+		client.mu.Unlock()
+		return errors.Errorf("response to unknown transaction %d", frame.Txnr)
+	}
+	delete(sess.inflight, frame.Txnr)
+	for i, unacked := range client.unacked {
+		if unacked == p {
+			client.unacked = append(client.unacked[:i], client.unacked[i+1:]...)
+			break
+		}
+	}
+	close(client.changed)
+	client.changed = make(chan struct{})
+	client.mu.Unlock()
+
+	if !rsp.ok() && client.cfg.ErrorHandler != nil {
+		client.cfg.ErrorHandler(errors.Errorf("server rejected message: %03d %s", rsp.code, rsp.text))
+	}
+	return nil
+}
+
+// Send implements rfc5424.Sender. The message is sent after applying
+// the client's defaults, unless the client's filter does not select
+// it, and kept until the server acknowledges it.
+//
+// If the connection has failed, Send first reconnects and sends the
+// unacknowledged messages again. An error means that the message was
+// not accepted, and it may be sent again.
+func (client *Client) Send(msg rfc5424.Message) error {
+	msg = client.cfg.Defaults.Apply(msg)
+	if client.cfg.Filter != nil && !client.cfg.Filter.Match(msg) {
+		return nil
+	}
+	return errors.Trace(client.send(msg.String()))
+}
+
+// SendRaw implements rfc5424.RawSender. The parsed message is sent as
+// for Send, but as it was received (see rfc5424.RawMessage.Verbatim),
+// with only the structured data of the client's defaults added.
+func (client *Client) SendRaw(raw *rfc5424.RawMessage) error {
+	raw = client.cfg.Defaults.ApplyRaw(raw)
+	if client.cfg.Filter != nil && !client.cfg.Filter.Match(raw.Message) {
+		return nil
+	}
+	return errors.Trace(client.send(raw.String()))
+}
+
+// send sends the message text, keeping it until the server
+// acknowledges it.
+func (client *Client) send(str string) error {
+	data := []byte(str)
+	if client.cfg.MaxSize > 0 && len(data) > client.cfg.MaxSize {
+		data = data[:client.cfg.MaxSize]
+	}
+	p := &pending{data: data}
+
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	timeout := client.timeout()
+	defer timeout.Stop()
+
+	retried := false
+	for {
+		if client.closed {
+			return errors.New("RELP client closed")
+		}
+		if err := client.ensureSession(); err != nil {
+			return errors.Trace(err)
+		}
+		if len(client.unacked) >= client.cfg.Window {
+			if err := client.wait(timeout.C); err != nil {
+				return errors.Annotate(err, "waiting for room in window")
+			}
+			continue
+		}
+		err := client.write(client.sess, p)
+		if err == nil {
+			client.unacked = append(client.unacked, p)
+			return nil
+		}
+		client.sess.fail(err)
+		if retried {
+			return errors.Trace(err)
+		}
+		retried = true
+	}
+}
+
+// Unacknowledged returns the number of messages that are waiting for
+// acknowledgement.
+func (client *Client) Unacknowledged() int {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	return len(client.unacked)
+}
+
+// Close waits for the server to acknowledge all of the messages that
+// were sent, reconnecting if necessary, then closes the session and
+// the connection. An error is returned if any were not acknowledged.
+func (client *Client) Close() error {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	if client.closed {
+		return nil
+	}
+	client.closed = true
+	timeout := client.timeout()
+	defer timeout.Stop()
+
+	var err error
+	for len(client.unacked) > 0 && err == nil {
+		if err = client.ensureSession(); err == nil {
+			err = client.wait(timeout.C)
+		}
+	}
+	if err != nil {
+		err = errors.Annotatef(err, "%d messages not acknowledged", len(client.unacked))
+	}
+
+	if sess := client.sess; sess != nil && !sess.isDead() {
+		sess.closeTxnr = sess.nextTxnr()
+		if sess.write(Frame{Txnr: sess.closeTxnr, Command: CommandClose}, client.cfg.SendTimeout) == nil {
+			client.mu.Unlock()
+			select {
+			case <-sess.closed:
+			case <-sess.dead:
+			case <-timeout.C:
+			}
+			client.mu.Lock()
+		}
+		sess.fail(errors.New("RELP client closed"))
+	}
+	return errors.Trace(err)
+}
+
+// ensureSession reconnects if the session has failed.
+func (client *Client) ensureSession() error {
+	if client.sess != nil && !client.sess.isDead() {
+		return nil
+	}
+	client.sess = nil
+	return errors.Trace(client.connect())
+}
+
+// wait waits, without holding the mutex, for a message to be
+// acknowledged or for the session to fail.
+func (client *Client) wait(timeout <-chan time.Time) error {
+	changed, dead := client.changed, client.sess.dead
+	client.mu.Unlock()
+	defer client.mu.Lock()
+	select {
+	case <-changed:
+		return nil
+	case <-dead:
+		return nil
+	case <-timeout:
+		return errors.New("timed out")
+	}
+}
+
+// timeout returns a timer for SendTimeout. If it is not set then the
+// timer never fires.
+func (client *Client) timeout() *time.Timer {
+	if client.cfg.SendTimeout > 0 {
+		return time.NewTimer(client.cfg.SendTimeout)
+	}
+	timer := time.NewTimer(time.Hour)
+	timer.Stop()
+	return timer
+}
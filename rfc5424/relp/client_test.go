@@ -0,0 +1,289 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package relp_test
+
+import (
+	"net"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/rfc/v2/rfc5424"
+	"github.com/juju/rfc/v2/rfc5424/relp"
+	"github.com/juju/rfc/v2/rfc5424/rfc5424test"
+)
+
+const longWait = 10 * time.Second
+
+type ClientSuite struct {
+	testing.IsolationSuite
+
+	received chan rfc5424test.Message
+	server   *rfc5424test.Server
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.received = make(chan rfc5424test.Message, 100)
+	s.server = rfc5424test.NewRELPServer(rfc5424test.HandlerFunc(func(msg rfc5424test.Message) {
+		s.received <- msg
+	}))
+	s.server.Start()
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+}
+
+func (s *ClientSuite) open(c *gc.C, cfg relp.ClientConfig) *relp.Client {
+	if cfg.SendTimeout == 0 {
+		cfg.SendTimeout = longWait
+	}
+	client, err := relp.Open(s.server.Addr().String(), cfg, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	return client
+}
+
+func (s *ClientSuite) send(c *gc.C, client *relp.Client, texts ...string) {
+	for _, text := range texts {
+		err := client.Send(newMessage(text))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *ClientSuite) next(c *gc.C) rfc5424test.Message {
+	select {
+	case msg := <-s.received:
+		return msg
+	case <-time.After(longWait):
+		c.Fatalf("timed out waiting for message")
+	}
+	panic("unreachable")
+}
+
+func newMessage(text string) rfc5424.Message {
+	return rfc5424.Message{
+		Header: rfc5424.Header{
+			Priority: rfc5424.Priority{
+				Severity: rfc5424.SeverityWarning,
+				Facility: rfc5424.FacilityDaemon,
+			},
+			AppName: "an-app",
+		},
+		Msg: text,
+	}
+}
+
+func (s *ClientSuite) TestValidate(c *gc.C) {
+	_, err := relp.Open(s.server.Addr().String(), relp.ClientConfig{Window: -1}, nil)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, "negative Window not valid")
+}
+
+func (s *ClientSuite) TestSend(c *gc.C) {
+	client := s.open(c, relp.ClientConfig{})
+	s.send(c, client, "one", "two")
+	c.Assert(client.Close(), jc.ErrorIsNil)
+	c.Check(client.Unacknowledged(), gc.Equals, 0)
+
+	for _, text := range []string{"one", "two"} {
+		msg := s.next(c)
+		c.Check(msg.ParseError, jc.ErrorIsNil)
+		c.Check(msg.Parsed.Msg, gc.Equals, text)
+		c.Check(msg.Framing, gc.Equals, rfc5424.FramingOctetCounting)
+	}
+}
+
+func (s *ClientSuite) TestSendDefaultsAndFilter(c *gc.C) {
+	cfg := relp.ClientConfig{}
+	cfg.Defaults.Hostname = rfc5424.Hostname{FQDN: "a.b.org"}
+	cfg.Filter = rfc5424.MatcherFunc(func(msg rfc5424.Message) bool {
+		return msg.Msg != "skip"
+	})
+	client := s.open(c, cfg)
+	s.send(c, client, "skip", "keep")
+	c.Assert(client.Close(), jc.ErrorIsNil)
+
+	msg := s.next(c)
+	c.Check(msg.Message, gc.Equals, "<28>1 - a.b.org an-app - - - keep")
+}
+
+func (s *ClientSuite) TestSendRaw(c *gc.C) {
+	client := s.open(c, relp.ClientConfig{})
+	text := `<28>1 1970-01-01T16:05:21+01:00 - - - - [spam x="\y"] a message`
+	raw, err := rfc5424.ParseRawMessage(text)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = client.SendRaw(raw)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(client.Close(), jc.ErrorIsNil)
+
+	c.Check(s.next(c).Message, gc.Equals, text)
+}
+
+func (s *ClientSuite) TestSendAfterClose(c *gc.C) {
+	client := s.open(c, relp.ClientConfig{})
+	c.Assert(client.Close(), jc.ErrorIsNil)
+	err := client.Send(newMessage("late"))
+	c.Check(err, gc.ErrorMatches, "RELP client closed")
+}
+
+func (s *ClientSuite) TestWindow(c *gc.C) {
+	s.server.SetFaults(rfc5424test.Faults{StallReads: true})
+	cfg := relp.ClientConfig{Window: 2}
+	cfg.SendTimeout = 100 * time.Millisecond
+	client := s.open(c, cfg)
+
+	s.send(c, client, "one", "two")
+	c.Check(client.Unacknowledged(), gc.Equals, 2)
+	err := client.Send(newMessage("three"))
+	c.Check(err, gc.ErrorMatches, "waiting for room in window: timed out")
+
+	s.server.SetFaults(rfc5424test.Faults{})
+	c.Check(s.next(c).Parsed.Msg, gc.Equals, "one")
+	c.Check(s.next(c).Parsed.Msg, gc.Equals, "two")
+	s.send(c, client, "three")
+	c.Assert(client.Close(), jc.ErrorIsNil)
+	c.Check(s.next(c).Parsed.Msg, gc.Equals, "three")
+}
+
+func (s *ClientSuite) TestResendAfterReconnect(c *gc.C) {
+	// The server drops each connection after two messages, without
+	// acknowledging the third, so the client must reconnect and send
+	// the unacknowledged messages again to deliver them all.
+	s.server.SetFaults(rfc5424test.Faults{DropAfter: 2})
+	client := s.open(c, relp.ClientConfig{})
+	texts := []string{"one", "two", "three", "four", "five", "six", "seven"}
+	s.send(c, client, texts...)
+	c.Assert(client.Close(), jc.ErrorIsNil)
+
+	var received []string
+	for len(received) < len(texts) {
+		text := s.next(c).Parsed.Msg
+		// A message may arrive twice if its acknowledgement was
+		// lost, but never out of order.
+		if len(received) > 0 && received[len(received)-1] == text {
+			continue
+		}
+		received = append(received, text)
+	}
+	c.Check(received, jc.DeepEquals, texts)
+}
+
+func (s *ClientSuite) TestOpenFails(c *gc.C) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	defer l.Close()
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		relp.NewFrameReader(conn, 0).ReadFrame()
+		conn.Write(relp.Frame{Txnr: 1, Command: relp.CommandResponse, Data: []byte("500 go away")}.Bytes())
+	}()
+
+	_, err = relp.Open(l.Addr().String(), relp.ClientConfig{}, nil)
+	c.Check(err, gc.ErrorMatches, "opening RELP session: server refused: 500 go away")
+}
+
+func (s *ClientSuite) TestRejected(c *gc.C) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	defer l.Close()
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		relp.ServeConn(conn, 0, func(data []byte) error {
+			return errors.New("no thanks")
+		})
+	}()
+
+	errs := make(chan error, 1)
+	cfg := relp.ClientConfig{
+		ErrorHandler: func(err error) {
+			errs <- err
+		},
+	}
+	cfg.SendTimeout = longWait
+	client, err := relp.Open(l.Addr().String(), cfg, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.send(c, client, "one")
+	c.Assert(client.Close(), jc.ErrorIsNil)
+	c.Check(client.Unacknowledged(), gc.Equals, 0)
+
+	select {
+	case err := <-errs:
+		c.Check(err, gc.ErrorMatches, "server rejected message: 500 no thanks")
+	case <-time.After(longWait):
+		c.Fatalf("timed out waiting for error")
+	}
+}
+
+func (s *ClientSuite) TestRepeatedCloseResponse(c *gc.C) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	defer l.Close()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := relp.NewFrameReader(conn, 0)
+		open, err := reader.ReadFrame()
+		if err != nil {
+			return
+		}
+		conn.Write(relp.Frame{
+			Txnr:    open.Txnr,
+			Command: relp.CommandResponse,
+			Data:    []byte("200 OK\nrelp_version=0\ncommands=syslog"),
+		}.Bytes())
+		closing, err := reader.ReadFrame()
+		if err != nil {
+			return
+		}
+		rsp := relp.Frame{Txnr: closing.Txnr, Command: relp.CommandResponse, Data: []byte("200 OK")}
+		conn.Write(append(rsp.Bytes(), rsp.Bytes()...))
+		reader.ReadFrame()
+	}()
+
+	cfg := relp.ClientConfig{}
+	cfg.SendTimeout = longWait
+	client, err := relp.Open(l.Addr().String(), cfg, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(client.Close(), jc.ErrorIsNil)
+	select {
+	case <-done:
+	case <-time.After(longWait):
+		c.Fatalf("timed out waiting for server")
+	}
+}
+
+func (s *ClientSuite) TestResponseTooLarge(c *gc.C) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	defer l.Close()
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		relp.NewFrameReader(conn, 0).ReadFrame()
+		conn.Write([]byte("1 rsp 999999999 200 OK"))
+	}()
+
+	_, err = relp.Open(l.Addr().String(), relp.ClientConfig{}, nil)
+	c.Check(err, gc.ErrorMatches, "opening RELP session: RELP frame too large")
+}
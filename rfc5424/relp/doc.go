@@ -0,0 +1,23 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+// The relp package implements the Reliable Event Logging Protocol,
+// which carries syslog messages over TCP with application-level
+// acknowledgements. With plain TCP, messages still buffered in the
+// kernel are lost without error when the connection drops; a RELP
+// client keeps each message until the server acknowledges it and
+// sends it again after reconnecting if necessary.
+//
+// A session starts with an open command, in which the client and
+// server exchange offers, followed by any number of syslog commands.
+// Each command has a transaction number, which the server's rsp
+// response repeats. The client may send a window of commands before
+// waiting for their responses. The client ends the session with a
+// close command, and a server may end it with serverclose.
+//
+// Client sends messages to a server. ServeConn serves a session on
+// a connection; the server package uses it to receive messages over
+// RELP, and the rfc5424test package to provide a test server.
+//
+// See https://www.rsyslog.com/doc/relp.html.
+package relp
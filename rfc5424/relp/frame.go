@@ -0,0 +1,194 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package relp
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+
+	"github.com/juju/errors"
+)
+
+// The RELP commands.
+const (
+	CommandOpen        = "open"
+	CommandSyslog      = "syslog"
+	CommandClose       = "close"
+	CommandResponse    = "rsp"
+	CommandServerClose = "serverclose"
+)
+
+const (
+	// MaxTxnr is the largest transaction number. The numbers of a
+	// session start at 1 and wrap around to 1 after MaxTxnr.
+	MaxTxnr = 999999999
+
+	// maxNumberDigits is the number of digits allowed in the
+	// transaction number and data length of a frame.
+	maxNumberDigits = 9
+
+	// maxCommandLength is the longest command allowed.
+	maxCommandLength = 32
+)
+
+// ErrFrameTooLarge is returned by FrameReader.ReadFrame when the data
+// of a frame exceeds the reader's maximum size.
+var ErrFrameTooLarge = errors.New("RELP frame too large")
+
+// Frame is a single RELP command or response.
+type Frame struct {
+	// Txnr is the transaction number, which a response shares with
+	// the command it answers. It is 0 for serverclose.
+	Txnr int
+
+	// Command is the name of the command, e.g. CommandSyslog.
+	Command string
+
+	// Data is the command's data, which may be empty.
+	Data []byte
+}
+
+// Bytes returns the frame as it is sent over the network.
+func (f Frame) Bytes() []byte {
+	header := strconv.Itoa(f.Txnr) + " " + f.Command + " " + strconv.Itoa(len(f.Data))
+	data := make([]byte, 0, len(header)+len(f.Data)+2)
+	data = append(data, header...)
+	if len(f.Data) > 0 {
+		data = append(data, ' ')
+		data = append(data, f.Data...)
+	}
+	return append(data, '\n')
+}
+
+// FrameReader reads RELP frames from a stream.
+type FrameReader struct {
+	r       *bufio.Reader
+	maxSize int
+}
+
+// NewFrameReader returns a FrameReader that reads from r. Frames
+// with more than maxSize octets of data result in ErrFrameTooLarge.
+// If maxSize is not positive then there is no maximum.
+func NewFrameReader(r io.Reader, maxSize int) *FrameReader {
+	return &FrameReader{
+		r:       bufio.NewReader(r),
+		maxSize: maxSize,
+	}
+}
+
+// ReadFrame returns the next frame. At the end of the stream io.EOF
+// is returned, or io.ErrUnexpectedEOF if the stream ends part way
+// through a frame.
+func (fr *FrameReader) ReadFrame() (Frame, error) {
+	if _, err := fr.r.Peek(1); err != nil {
+		return Frame{}, err
+	}
+
+	txnr, _, err := fr.readNumber("transaction number", false)
+	if err != nil {
+		return Frame{}, err
+	}
+	command, err := fr.readCommand()
+	if err != nil {
+		return Frame{}, err
+	}
+	size, last, err := fr.readNumber("data length", true)
+	if err != nil {
+		return Frame{}, err
+	}
+	frame := Frame{Txnr: txnr, Command: command}
+	if size == 0 {
+		if last == ' ' {
+			// Tolerate a separator without any data.
+			if last, err = fr.readByte(); err != nil {
+				return Frame{}, err
+			}
+		}
+		if last != '\n' {
+			return Frame{}, errors.Errorf("missing RELP frame trailer")
+		}
+		return frame, nil
+	}
+	if last != ' ' {
+		return Frame{}, errors.Errorf("missing RELP frame data")
+	}
+	if fr.maxSize > 0 && size > fr.maxSize {
+		return Frame{}, ErrFrameTooLarge
+	}
+
+	frame.Data = make([]byte, size)
+	if _, err := io.ReadFull(fr.r, frame.Data); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return Frame{}, err
+	}
+	trailer, err := fr.readByte()
+	if err != nil {
+		return Frame{}, err
+	}
+	if trailer != '\n' {
+		return Frame{}, errors.Errorf("missing RELP frame trailer")
+	}
+	return frame, nil
+}
+
+// readNumber reads a number and the octet that follows it, which
+// must be a space or, if allowLF is set, a LF.
+func (fr *FrameReader) readNumber(what string, allowLF bool) (int, byte, error) {
+	var digits []byte
+	for {
+		c, err := fr.readByte()
+		if err != nil {
+			return 0, 0, err
+		}
+		if c < '0' || c > '9' {
+			if len(digits) == 0 || !(c == ' ' || (c == '\n' && allowLF)) {
+				return 0, 0, errors.Errorf("bad RELP %s", what)
+			}
+			n, err := strconv.Atoi(string(digits))
+			if err != nil {
+				return 0, 0, errors.Errorf("bad RELP %s %q", what, digits)
+			}
+			return n, c, nil
+		}
+		if len(digits) == maxNumberDigits {
+			return 0, 0, errors.Errorf("RELP %s too long", what)
+		}
+		digits = append(digits, c)
+	}
+}
+
+func (fr *FrameReader) readCommand() (string, error) {
+	var command []byte
+	for {
+		c, err := fr.readByte()
+		if err != nil {
+			return "", err
+		}
+		if c == ' ' && len(command) > 0 {
+			return string(command), nil
+		}
+		if !isAlpha(c) {
+			return "", errors.Errorf("bad RELP command")
+		}
+		if len(command) == maxCommandLength {
+			return "", errors.Errorf("RELP command too long")
+		}
+		command = append(command, c)
+	}
+}
+
+func (fr *FrameReader) readByte() (byte, error) {
+	c, err := fr.r.ReadByte()
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return c, err
+}
+
+func isAlpha(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
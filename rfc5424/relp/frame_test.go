@@ -0,0 +1,116 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package relp_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/rfc/v2/rfc5424/relp"
+)
+
+type FrameSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FrameSuite{})
+
+func (s *FrameSuite) TestBytes(c *gc.C) {
+	tests := []struct {
+		frame    relp.Frame
+		expected string
+	}{{
+		frame:    relp.Frame{Txnr: 1, Command: relp.CommandSyslog, Data: []byte("<28>1 - - - - - -")},
+		expected: "1 syslog 17 <28>1 - - - - - -\n",
+	}, {
+		frame:    relp.Frame{Txnr: 42, Command: relp.CommandClose},
+		expected: "42 close 0\n",
+	}, {
+		frame:    relp.Frame{Command: relp.CommandServerClose},
+		expected: "0 serverclose 0\n",
+	}}
+	for i, test := range tests {
+		c.Logf("test %d: %q", i, test.expected)
+		c.Check(string(test.frame.Bytes()), gc.Equals, test.expected)
+	}
+}
+
+func (s *FrameSuite) TestReadFrame(c *gc.C) {
+	frames := []relp.Frame{
+		{Txnr: 1, Command: relp.CommandOpen, Data: []byte("relp_version=0\ncommands=syslog")},
+		{Txnr: 2, Command: relp.CommandSyslog, Data: []byte("a message\nwith a LF")},
+		{Txnr: relp.MaxTxnr, Command: relp.CommandClose},
+	}
+	var stream bytes.Buffer
+	for _, frame := range frames {
+		stream.Write(frame.Bytes())
+	}
+
+	reader := relp.NewFrameReader(&stream, 0)
+	for _, expected := range frames {
+		frame, err := reader.ReadFrame()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(frame, jc.DeepEquals, expected)
+	}
+	_, err := reader.ReadFrame()
+	c.Check(err, gc.Equals, io.EOF)
+}
+
+func (s *FrameSuite) TestReadFrameSeparatorWithoutData(c *gc.C) {
+	reader := relp.NewFrameReader(strings.NewReader("3 rsp 0 \n"), 0)
+	frame, err := reader.ReadFrame()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(frame, jc.DeepEquals, relp.Frame{Txnr: 3, Command: relp.CommandResponse})
+}
+
+func (s *FrameSuite) TestReadFrameErrors(c *gc.C) {
+	tests := []struct {
+		stream string
+		err    string
+	}{{
+		stream: "x syslog 0\n",
+		err:    "bad RELP transaction number",
+	}, {
+		stream: "1\nsyslog 0\n",
+		err:    "bad RELP transaction number",
+	}, {
+		stream: "1234567890 syslog 0\n",
+		err:    "RELP transaction number too long",
+	}, {
+		stream: "1 sys-log 0\n",
+		err:    "bad RELP command",
+	}, {
+		stream: "1 " + strings.Repeat("x", 33) + " 0\n",
+		err:    "RELP command too long",
+	}, {
+		stream: "1 syslog 5\n",
+		err:    "missing RELP frame data",
+	}, {
+		stream: "1 syslog 0 x",
+		err:    "missing RELP frame trailer",
+	}, {
+		stream: "1 syslog 3 abcd\n",
+		err:    "missing RELP frame trailer",
+	}, {
+		stream: "1 syslog 11 a message\n",
+		err:    "RELP frame too large",
+	}, {
+		stream: "1 syslog 10 a mess",
+		err:    "unexpected EOF",
+	}, {
+		stream: "1 sys",
+		err:    "unexpected EOF",
+	}}
+	for i, test := range tests {
+		c.Logf("test %d: %q", i, test.stream)
+		reader := relp.NewFrameReader(strings.NewReader(test.stream), 10)
+		_, err := reader.ReadFrame()
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
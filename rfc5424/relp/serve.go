@@ -0,0 +1,139 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package relp
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+const (
+	// relpVersion is the version of the protocol that is offered.
+	relpVersion = "0"
+
+	// software is the relp_software offer.
+	software = "github.com/juju/rfc"
+)
+
+// response is the data of a rsp frame: a status code, a message for
+// humans and, for the response to open, the server's offers.
+type response struct {
+	code int
+	text string
+	data []byte
+}
+
+func (r response) ok() bool {
+	return r.code == 200
+}
+
+func (r response) bytes() []byte {
+	text := strings.ReplaceAll(r.text, "\n", " ")
+	data := []byte(fmt.Sprintf("%03d %s", r.code, text))
+	if len(r.data) > 0 {
+		data = append(data, '\n')
+		data = append(data, r.data...)
+	}
+	return data
+}
+
+func parseResponse(data []byte) (response, error) {
+	line, rest, _ := bytes.Cut(data, []byte("\n"))
+	code, text, _ := strings.Cut(string(line), " ")
+	n, err := strconv.Atoi(code)
+	if err != nil || len(code) != 3 {
+		return response{}, errors.Errorf("bad RELP response code %q", code)
+	}
+	return response{code: n, text: text, data: rest}, nil
+}
+
+// offers returns the offers of an open command or its response.
+func offers(commands ...string) []byte {
+	return []byte("relp_version=" + relpVersion +
+		"\nrelp_software=" + software +
+		"\ncommands=" + strings.Join(commands, ","))
+}
+
+// parseOffers returns the values of the offers, keyed by name.
+func parseOffers(data []byte) map[string]string {
+	values := make(map[string]string)
+	for _, line := range strings.Split(string(data), "\n") {
+		name, value, _ := strings.Cut(line, "=")
+		if name != "" {
+			values[name] = value
+		}
+	}
+	return values
+}
+
+// offersCommand reports whether the offers include the command.
+func offersCommand(values map[string]string, command string) bool {
+	for _, offered := range strings.Split(values["commands"], ",") {
+		if offered == command {
+			return true
+		}
+	}
+	return false
+}
+
+// ServeConn serves a RELP session on the connection, calling handle
+// with the data of each syslog command. Each command is acknowledged
+// once handle returns: with status 200 if it returned nil and with
+// status 500 and the error otherwise. Syslog commands with more than
+// maxSize octets of data end the session; if maxSize is not positive
+// then there is no maximum.
+//
+// ServeConn returns nil once the client closes the session. Otherwise
+// it returns the error that ended the session, which is io.EOF if the
+// client disconnected without closing it. The connection is not
+// closed.
+func ServeConn(conn io.ReadWriter, maxSize int, handle func(data []byte) error) error {
+	reader := NewFrameReader(conn, maxSize)
+	respond := func(txnr int, rsp response) error {
+		frame := Frame{Txnr: txnr, Command: CommandResponse, Data: rsp.bytes()}
+		_, err := conn.Write(frame.Bytes())
+		return errors.Trace(err)
+	}
+
+	opened := false
+	for {
+		frame, err := reader.ReadFrame()
+		if err != nil {
+			return err
+		}
+		switch {
+		case frame.Command == CommandOpen && !opened:
+			if !offersCommand(parseOffers(frame.Data), CommandSyslog) {
+				respond(frame.Txnr, response{code: 500, text: "syslog command not offered"})
+				return errors.New("client did not offer the syslog command")
+			}
+			if err := respond(frame.Txnr, response{code: 200, text: "OK", data: offers(CommandSyslog)}); err != nil {
+				return errors.Trace(err)
+			}
+			opened = true
+		case !opened:
+			respond(frame.Txnr, response{code: 500, text: "session not open"})
+			return errors.Errorf("RELP command %q before open", frame.Command)
+		case frame.Command == CommandSyslog:
+			rsp := response{code: 200, text: "OK"}
+			if err := handle(frame.Data); err != nil {
+				rsp = response{code: 500, text: err.Error()}
+			}
+			if err := respond(frame.Txnr, rsp); err != nil {
+				return errors.Trace(err)
+			}
+		case frame.Command == CommandClose:
+			return errors.Trace(respond(frame.Txnr, response{code: 200, text: "OK"}))
+		default:
+			if err := respond(frame.Txnr, response{code: 500, text: "unsupported command"}); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
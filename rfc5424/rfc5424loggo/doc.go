@@ -0,0 +1,6 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+// The rfc5424loggo package provides a loggo.Writer that forwards log
+// entries as RFC 5424 syslog messages.
+package rfc5424loggo
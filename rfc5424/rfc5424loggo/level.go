@@ -0,0 +1,17 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package rfc5424loggo
+
+import (
+	"github.com/juju/loggo"
+
+	"github.com/juju/rfc/v2/rfc5424"
+	"github.com/juju/rfc/v2/rfc5424/levels"
+)
+
+// LevelSeverity returns the severity that corresponds to the loggo
+// level, as described by levels.FromLoggo.
+func LevelSeverity(level loggo.Level) rfc5424.Severity {
+	return levels.FromLoggo(level)
+}
@@ -0,0 +1,123 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package rfc5424loggo
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/rfc/v2/rfc5424"
+	"github.com/juju/rfc/v2/rfc5424/sdelements"
+)
+
+// DefaultSDName is the structured data element name used for the
+// entry's module and location when Options.SDName is not set.
+const DefaultSDName rfc5424.StructuredDataName = "log"
+
+// Options configures a Writer.
+type Options struct {
+	// Header is the template for the header of each message. Its
+	// Severity is replaced with the one for the entry's level (see
+	// LevelSeverity) and its Timestamp with the entry's timestamp.
+	Header rfc5424.Header
+
+	// SDName is the name of the private structured data element that
+	// holds the entry's module, location and labels. If not set,
+	// DefaultSDName is used.
+	SDName rfc5424.StructuredDataName
+
+	// PEN is the Private Enterprise Number of the structured data
+	// element.
+	PEN sdelements.PrivateEnterpriseNumber
+
+	// ErrorHandler, if set, is called with any error from sending a
+	// message, since loggo.Writer has no way to report them.
+	ErrorHandler func(error)
+}
+
+// Validate ensures that the options are correct.
+func (opts Options) Validate() error {
+	if err := opts.Header.Validate(); err != nil {
+		return errors.NotValidf("Header (%v)", err)
+	}
+	if err := opts.element(nil).Validate(); err != nil {
+		return errors.NotValidf("structured data element (%v)", err)
+	}
+	return nil
+}
+
+func (opts Options) element(params []rfc5424.StructuredDataParam) sdelements.Private {
+	name := opts.SDName
+	if name == "" {
+		name = DefaultSDName
+	}
+	return sdelements.Private{
+		Name: name,
+		PEN:  opts.PEN,
+		Data: params,
+	}
+}
+
+// Writer is a loggo.Writer that sends each entry as a syslog message.
+// The entry's message becomes Msg and the rest of it is carried in a
+// single private structured data element with these params:
+//
+//	module  the entry's module, e.g. "juju.worker"
+//	source  the entry's location as "file:line"
+//	label   one param for each of the entry's labels
+type Writer struct {
+	sender rfc5424.Sender
+	opts   Options
+}
+
+var _ loggo.Writer = (*Writer)(nil)
+
+// NewWriter returns a writer that sends entries to the sender,
+// typically an *rfc5424.Client.
+func NewWriter(sender rfc5424.Sender, opts Options) (*Writer, error) {
+	if sender == nil {
+		return nil, errors.NotValidf("nil sender")
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Writer{
+		sender: sender,
+		opts:   opts,
+	}
+	return w, nil
+}
+
+// Write implements loggo.Writer.
+func (w *Writer) Write(entry loggo.Entry) {
+	if err := w.sender.Send(w.message(entry)); err != nil && w.opts.ErrorHandler != nil {
+		w.opts.ErrorHandler(errors.Trace(err))
+	}
+}
+
+func (w *Writer) message(entry loggo.Entry) rfc5424.Message {
+	var params []rfc5424.StructuredDataParam
+	if entry.Module != "" {
+		params = append(params, sdelements.NewParam("module", entry.Module))
+	}
+	if entry.Filename != "" {
+		params = append(params, sdelements.NewParam("source", fmt.Sprintf("%s:%d", entry.Filename, entry.Line)))
+	}
+	for _, label := range entry.Labels {
+		params = append(params, sdelements.NewParam("label", label))
+	}
+
+	msg := rfc5424.Message{
+		Header: w.opts.Header,
+		Msg:    entry.Message,
+	}
+	msg.Severity = LevelSeverity(entry.Level)
+	msg.Timestamp = rfc5424.Timestamp{Time: entry.Timestamp}
+	if len(params) > 0 {
+		msg.StructuredData = rfc5424.StructuredData{w.opts.element(params)}
+	}
+	return msg
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package rfc5424loggo_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/rfc/v2/rfc5424"
+	"github.com/juju/rfc/v2/rfc5424/rfc5424loggo"
+	"github.com/juju/rfc/v2/rfc5424/rfc5424test"
+)
+
+type WriterSuite struct {
+	testing.IsolationSuite
+
+	sender *recordingSender
+}
+
+var _ = gc.Suite(&WriterSuite{})
+
+func (s *WriterSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.sender = &recordingSender{}
+}
+
+func (s *WriterSuite) TestWrite(c *gc.C) {
+	w, err := rfc5424loggo.NewWriter(s.sender, rfc5424loggo.Options{
+		Header: rfc5424.Header{
+			Priority: rfc5424.Priority{Facility: rfc5424.FacilityDaemon},
+			Hostname: rfc5424.Hostname{FQDN: "a.b.org"},
+			AppName:  "jujud",
+		},
+		PEN: 28978,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	w.Write(loggo.Entry{
+		Level:     loggo.WARNING,
+		Module:    "juju.worker",
+		Filename:  "worker.go",
+		Line:      42,
+		Timestamp: time.Unix(54321, 0).UTC(),
+		Message:   "a message",
+		Labels:    []string{"http", "api"},
+	})
+
+	c.Assert(s.sender.messages, gc.HasLen, 1)
+	msg := s.sender.messages[0]
+	c.Check(msg.String(), gc.Equals, `<28>1 1970-01-01T15:05:21Z a.b.org jujud - - [log@28978 module="juju.worker" source="worker.go:42" label="http" label="api"] a message`)
+	c.Check(msg.Validate(), jc.ErrorIsNil)
+}
+
+func (s *WriterSuite) TestWriteMinimal(c *gc.C) {
+	w, err := rfc5424loggo.NewWriter(s.sender, rfc5424loggo.Options{PEN: 28978, SDName: "juju"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	w.Write(loggo.Entry{Level: loggo.INFO, Message: "a message"})
+
+	c.Assert(s.sender.messages, gc.HasLen, 1)
+	c.Check(s.sender.messages[0].String(), gc.Equals, `<14>1 - - - - - - a message`)
+}
+
+func (s *WriterSuite) TestWriteError(c *gc.C) {
+	var reported error
+	s.sender.err = errors.New("boom")
+	w, err := rfc5424loggo.NewWriter(s.sender, rfc5424loggo.Options{
+		PEN:          28978,
+		ErrorHandler: func(err error) { reported = err },
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	w.Write(loggo.Entry{Level: loggo.INFO, Message: "a message"})
+
+	c.Check(reported, gc.ErrorMatches, "boom")
+}
+
+func (s *WriterSuite) TestLoggo(c *gc.C) {
+	recorder := rfc5424test.NewRecorder()
+	server := rfc5424test.NewServer(recorder)
+	server.Start()
+	defer server.Close()
+	client, err := rfc5424.Open(server.Addr().String(), rfc5424.ClientConfig{}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	defer client.Close()
+
+	w, err := rfc5424loggo.NewWriter(client, rfc5424loggo.Options{PEN: 28978})
+	c.Assert(err, jc.ErrorIsNil)
+	context := loggo.NewContext(loggo.DEBUG)
+	err = context.AddWriter("syslog", w)
+	c.Assert(err, jc.ErrorIsNil)
+
+	context.GetLogger("juju.test").Errorf("oops %d", 1)
+
+	messages, err := recorder.WaitForN(1, 10*time.Second)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(messages[0], rfc5424test.HasSeverity, rfc5424.SeverityError)
+	c.Check(messages[0], rfc5424test.HasSDParam, "log@28978", "module", "juju.test")
+	c.Check(messages[0].Parsed.Msg, gc.Equals, "oops 1")
+}
+
+func (s *WriterSuite) TestNewWriterValidates(c *gc.C) {
+	_, err := rfc5424loggo.NewWriter(s.sender, rfc5424loggo.Options{})
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+
+	_, err = rfc5424loggo.NewWriter(nil, rfc5424loggo.Options{PEN: 28978})
+	c.Check(err, gc.ErrorMatches, "nil sender not valid")
+}
+
+func (s *WriterSuite) TestLevelSeverity(c *gc.C) {
+	for level, severity := range map[loggo.Level]rfc5424.Severity{
+		loggo.UNSPECIFIED: rfc5424.SeverityInformational,
+		loggo.TRACE:       rfc5424.SeverityDebug,
+		loggo.DEBUG:       rfc5424.SeverityDebug,
+		loggo.INFO:        rfc5424.SeverityInformational,
+		loggo.WARNING:     rfc5424.SeverityWarning,
+		loggo.ERROR:       rfc5424.SeverityError,
+		loggo.CRITICAL:    rfc5424.SeverityCrit,
+	} {
+		c.Check(rfc5424loggo.LevelSeverity(level), gc.Equals, severity, gc.Commentf("%v", level))
+	}
+}
+
+type recordingSender struct {
+	messages []rfc5424.Message
+	err      error
+}
+
+func (s *recordingSender) Send(msg rfc5424.Message) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.messages = append(s.messages, msg)
+	return nil
+}
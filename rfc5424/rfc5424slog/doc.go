@@ -0,0 +1,6 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+// The rfc5424slog package provides a log/slog Handler that sends each
+// log record as an RFC 5424 syslog message.
+package rfc5424slog
@@ -0,0 +1,176 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package rfc5424slog
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/rfc/v2/rfc5424"
+	"github.com/juju/rfc/v2/rfc5424/sdelements"
+)
+
+// DefaultSDName is the structured data element name used for record
+// attributes when Options.SDName is not set.
+const DefaultSDName rfc5424.StructuredDataName = "slog"
+
+// Options configures a Handler.
+type Options struct {
+	// Level is the minimum level that is sent. If not set then
+	// slog.LevelInfo is used.
+	Level slog.Leveler
+
+	// Header is the template for the header of each message. Its
+	// Severity is replaced with the one for the record's level and
+	// its Timestamp with the record's time.
+	Header rfc5424.Header
+
+	// SDName is the name of the private structured data element that
+	// holds the record's attributes. If not set, DefaultSDName is used.
+	SDName rfc5424.StructuredDataName
+
+	// PEN is the Private Enterprise Number of the structured data
+	// element that holds the record's attributes.
+	PEN sdelements.PrivateEnterpriseNumber
+
+	// AddSource adds the record's source location as the "source"
+	// attribute, formatted as "file:line".
+	AddSource bool
+}
+
+// Validate ensures that the options are correct.
+func (opts Options) Validate() error {
+	if err := opts.Header.Validate(); err != nil {
+		return errors.NotValidf("Header (%v)", err)
+	}
+	if err := opts.element(nil).Validate(); err != nil {
+		return errors.NotValidf("structured data element (%v)", err)
+	}
+	return nil
+}
+
+func (opts Options) element(params []rfc5424.StructuredDataParam) sdelements.Private {
+	name := opts.SDName
+	if name == "" {
+		name = DefaultSDName
+	}
+	return sdelements.Private{
+		Name: name,
+		PEN:  opts.PEN,
+		Data: params,
+	}
+}
+
+// Handler is a slog.Handler that sends records as syslog messages.
+// The record's level determines the message severity (see
+// LevelSeverity), its message becomes Msg and its attributes become
+// params of a single private structured data element. Attributes in
+// groups are named with the group path, e.g. "req.method".
+type Handler struct {
+	sender rfc5424.Sender
+	opts   Options
+
+	prefix string
+	params []rfc5424.StructuredDataParam
+}
+
+// NewHandler returns a handler that sends records to the sender,
+// typically an *rfc5424.Client.
+func NewHandler(sender rfc5424.Sender, opts Options) (*Handler, error) {
+	if sender == nil {
+		return nil, errors.NotValidf("nil sender")
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if opts.Level == nil {
+		opts.Level = slog.LevelInfo
+	}
+	h := &Handler{
+		sender: sender,
+		opts:   opts,
+	}
+	return h, nil
+}
+
+// Enabled implements slog.Handler.
+func (h *Handler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.opts.Level.Level()
+}
+
+// Handle implements slog.Handler.
+func (h *Handler) Handle(_ context.Context, record slog.Record) error {
+	params := make([]rfc5424.StructuredDataParam, len(h.params), len(h.params)+record.NumAttrs()+1)
+	copy(params, h.params)
+	if h.opts.AddSource && record.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+		params = append(params, sdelements.NewParam("source", fmt.Sprintf("%s:%d", frame.File, frame.Line)))
+	}
+	record.Attrs(func(attr slog.Attr) bool {
+		params = appendAttr(params, h.prefix, attr)
+		return true
+	})
+
+	msg := rfc5424.Message{
+		Header: h.opts.Header,
+		Msg:    record.Message,
+	}
+	msg.Severity = LevelSeverity(record.Level)
+	msg.Timestamp = rfc5424.Timestamp{Time: record.Time}
+	if len(params) > 0 {
+		msg.StructuredData = rfc5424.StructuredData{h.opts.element(params)}
+	}
+	return errors.Trace(h.sender.Send(msg))
+}
+
+// WithAttrs implements slog.Handler.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	h2 := *h
+	h2.params = make([]rfc5424.StructuredDataParam, len(h.params), len(h.params)+len(attrs))
+	copy(h2.params, h.params)
+	for _, attr := range attrs {
+		h2.params = appendAttr(h2.params, h.prefix, attr)
+	}
+	return &h2
+}
+
+// WithGroup implements slog.Handler.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.prefix = h.prefix + name + "."
+	return &h2
+}
+
+func appendAttr(params []rfc5424.StructuredDataParam, prefix string, attr slog.Attr) []rfc5424.StructuredDataParam {
+	attr.Value = attr.Value.Resolve()
+	if attr.Key == "" && attr.Value.Kind() != slog.KindGroup {
+		return params
+	}
+
+	switch attr.Value.Kind() {
+	case slog.KindGroup:
+		if attr.Key != "" {
+			prefix += attr.Key + "."
+		}
+		for _, groupAttr := range attr.Value.Group() {
+			params = appendAttr(params, prefix, groupAttr)
+		}
+		return params
+	case slog.KindTime:
+		return append(params, sdelements.NewParam(prefix+attr.Key, attr.Value.Time().Format(time.RFC3339Nano)))
+	default:
+		return append(params, sdelements.NewParam(prefix+attr.Key, attr.Value.String()))
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package rfc5424slog_test
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing/slogtest"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/rfc/v2/rfc5424"
+	"github.com/juju/rfc/v2/rfc5424/rfc5424slog"
+)
+
+type HandlerSuite struct {
+	testing.IsolationSuite
+
+	sender *recordingSender
+}
+
+var _ = gc.Suite(&HandlerSuite{})
+
+func (s *HandlerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.sender = &recordingSender{}
+}
+
+func (s *HandlerSuite) newHandler(c *gc.C, opts rfc5424slog.Options) *rfc5424slog.Handler {
+	if opts.PEN == 0 {
+		opts.PEN = 32473
+	}
+	h, err := rfc5424slog.NewHandler(s.sender, opts)
+	c.Assert(err, jc.ErrorIsNil)
+	return h
+}
+
+func (s *HandlerSuite) TestHandle(c *gc.C) {
+	h := s.newHandler(c, rfc5424slog.Options{
+		Header: rfc5424.Header{
+			Priority: rfc5424.Priority{Facility: rfc5424.FacilityDaemon},
+			Hostname: rfc5424.Hostname{FQDN: "a.b.org"},
+			AppName:  "an-app",
+		},
+		SDName: "app",
+	})
+	logger := slog.New(h).With("user", "bob").WithGroup("req")
+
+	logger.Warn("a message", "method", "GET", slog.Group("url", "path", "/x"))
+
+	c.Assert(s.sender.messages, gc.HasLen, 1)
+	msg := s.sender.messages[0]
+	c.Check(msg.Severity, gc.Equals, rfc5424.SeverityWarning)
+	c.Check(msg.Facility, gc.Equals, rfc5424.FacilityDaemon)
+	c.Check(msg.AppName, gc.Equals, rfc5424.AppName("an-app"))
+	c.Check(msg.Msg, gc.Equals, "a message")
+	c.Check(msg.Timestamp.IsZero(), jc.IsFalse)
+	c.Check(msg.StructuredData.String(), gc.Equals, `[app@32473 user="bob" req.method="GET" req.url.path="/x"]`)
+	c.Check(msg.Validate(), jc.ErrorIsNil)
+}
+
+func (s *HandlerSuite) TestHandleNoAttrs(c *gc.C) {
+	h := s.newHandler(c, rfc5424slog.Options{})
+
+	slog.New(h).Error("a message")
+
+	c.Assert(s.sender.messages, gc.HasLen, 1)
+	c.Check(s.sender.messages[0].Severity, gc.Equals, rfc5424.SeverityError)
+	c.Check(s.sender.messages[0].StructuredData, gc.HasLen, 0)
+}
+
+func (s *HandlerSuite) TestHandleInvalidNames(c *gc.C) {
+	h := s.newHandler(c, rfc5424slog.Options{})
+
+	slog.New(h).Info("a message", `a "b"=c]`, 1, strings.Repeat("x", 40), true)
+
+	c.Assert(s.sender.messages, gc.HasLen, 1)
+	msg := s.sender.messages[0]
+	c.Check(msg.StructuredData.String(), gc.Equals, `[slog@32473 a__b__c_="1" `+strings.Repeat("x", 32)+`="true"]`)
+	c.Check(msg.Validate(), jc.ErrorIsNil)
+}
+
+func (s *HandlerSuite) TestAddSource(c *gc.C) {
+	h := s.newHandler(c, rfc5424slog.Options{AddSource: true})
+
+	slog.New(h).Info("a message")
+
+	c.Assert(s.sender.messages, gc.HasLen, 1)
+	params := s.sender.messages[0].StructuredData[0].Params()
+	c.Assert(params, gc.HasLen, 1)
+	c.Check(params[0].Name, gc.Equals, rfc5424.StructuredDataName("source"))
+	c.Check(string(params[0].Value), gc.Matches, `.*/handler_test.go:\d+`)
+}
+
+func (s *HandlerSuite) TestEnabled(c *gc.C) {
+	h := s.newHandler(c, rfc5424slog.Options{Level: slog.LevelWarn})
+
+	c.Check(h.Enabled(context.Background(), slog.LevelInfo), jc.IsFalse)
+	c.Check(h.Enabled(context.Background(), slog.LevelWarn), jc.IsTrue)
+}
+
+func (s *HandlerSuite) TestSendError(c *gc.C) {
+	s.sender.err = errors.New("boom")
+	h := s.newHandler(c, rfc5424slog.Options{})
+
+	err := h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "a message", 0))
+
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *HandlerSuite) TestNewHandlerValidates(c *gc.C) {
+	_, err := rfc5424slog.NewHandler(s.sender, rfc5424slog.Options{})
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `structured data element \(empty PEN\) not valid`)
+
+	_, err = rfc5424slog.NewHandler(nil, rfc5424slog.Options{PEN: 32473})
+	c.Check(err, gc.ErrorMatches, `nil sender not valid`)
+}
+
+func (s *HandlerSuite) TestSlogTest(c *gc.C) {
+	h := s.newHandler(c, rfc5424slog.Options{})
+
+	err := slogtest.TestHandler(h, func() []map[string]any {
+		results := make([]map[string]any, len(s.sender.messages))
+		for i, msg := range s.sender.messages {
+			results[i] = messageToMap(msg)
+		}
+		return results
+	})
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *HandlerSuite) TestLevelSeverity(c *gc.C) {
+	for level, severity := range map[slog.Level]rfc5424.Severity{
+		slog.LevelDebug - 4:  rfc5424.SeverityDebug,
+		slog.LevelDebug:      rfc5424.SeverityDebug,
+		slog.LevelInfo:       rfc5424.SeverityInformational,
+		slog.LevelInfo + 2:   rfc5424.SeverityNotice,
+		slog.LevelWarn:       rfc5424.SeverityWarning,
+		slog.LevelWarn + 2:   rfc5424.SeverityWarning,
+		slog.LevelError:      rfc5424.SeverityError,
+		slog.LevelError + 4:  rfc5424.SeverityCrit,
+		slog.LevelError + 8:  rfc5424.SeverityAlert,
+		slog.LevelError + 9:  rfc5424.SeverityAlert,
+		slog.LevelError + 12: rfc5424.SeverityEmergency,
+	} {
+		c.Check(rfc5424slog.LevelSeverity(level), gc.Equals, severity, gc.Commentf("%v", level))
+	}
+}
+
+// messageToMap converts a message back into the form slogtest
+// expects, nesting params named with a group path.
+func messageToMap(msg rfc5424.Message) map[string]any {
+	m := map[string]any{
+		slog.MessageKey: msg.Msg,
+		slog.LevelKey:   msg.Severity,
+	}
+	if !msg.Timestamp.IsZero() {
+		m[slog.TimeKey] = msg.Timestamp.Time
+	}
+	for _, elem := range msg.StructuredData {
+		for _, param := range elem.Params() {
+			path := strings.Split(string(param.Name), ".")
+			group := m
+			for _, name := range path[:len(path)-1] {
+				sub, ok := group[name].(map[string]any)
+				if !ok {
+					sub = make(map[string]any)
+					group[name] = sub
+				}
+				group = sub
+			}
+			group[path[len(path)-1]] = string(param.Value)
+		}
+	}
+	return m
+}
+
+type recordingSender struct {
+	messages []rfc5424.Message
+	err      error
+}
+
+func (s *recordingSender) Send(msg rfc5424.Message) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.messages = append(s.messages, msg)
+	return nil
+}
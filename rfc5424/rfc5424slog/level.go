@@ -0,0 +1,17 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package rfc5424slog
+
+import (
+	"log/slog"
+
+	"github.com/juju/rfc/v2/rfc5424"
+	"github.com/juju/rfc/v2/rfc5424/levels"
+)
+
+// LevelSeverity returns the severity that corresponds to the slog
+// level, as described by levels.FromSlog.
+func LevelSeverity(level slog.Level) rfc5424.Severity {
+	return levels.FromSlog(level)
+}
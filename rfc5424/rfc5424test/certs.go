@@ -0,0 +1,183 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package rfc5424test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"net"
+	"sync/atomic"
+	"time"
+)
+
+// certValidity is how long certificates issued by a CA are valid.
+const certValidity = 24 * time.Hour
+
+// DefaultHosts are the hosts that server certificates are issued for
+// when none are given. They match the address NewServer listens on.
+var DefaultHosts = []string{"127.0.0.1", "localhost"}
+
+// CA is a throwaway, in-memory certificate authority for TLS tests.
+type CA struct {
+	// Cert is the CA's self-signed certificate.
+	Cert *x509.Certificate
+
+	// Key is the CA's private key.
+	Key crypto.Signer
+
+	// Pool is a certificate pool holding only Cert.
+	Pool *x509.CertPool
+
+	serial int64
+}
+
+// NewCA creates a new CA with a freshly generated key.
+func NewCA() *CA {
+	key := newKey()
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "rfc5424test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(certValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		panic(fmt.Sprintf("NewCA: %v", err))
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		panic(fmt.Sprintf("NewCA: %v", err))
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	return &CA{
+		Cert:   cert,
+		Key:    key,
+		Pool:   pool,
+		serial: 1,
+	}
+}
+
+// ServerCert issues a server certificate for the given hosts, which
+// may be names or IP addresses. If no hosts are given, DefaultHosts
+// is used.
+func (ca *CA) ServerCert(hosts ...string) tls.Certificate {
+	if len(hosts) == 0 {
+		hosts = DefaultHosts
+	}
+	return ca.issue(hosts[0], hosts, x509.ExtKeyUsageServerAuth, time.Now().Add(-time.Hour))
+}
+
+// ExpiredServerCert is like ServerCert except that the certificate
+// has already expired.
+func (ca *CA) ExpiredServerCert(hosts ...string) tls.Certificate {
+	if len(hosts) == 0 {
+		hosts = DefaultHosts
+	}
+	return ca.issue(hosts[0], hosts, x509.ExtKeyUsageServerAuth, time.Now().Add(-2*certValidity))
+}
+
+// ClientCert issues a client certificate with the given common name.
+func (ca *CA) ClientCert(commonName string) tls.Certificate {
+	return ca.issue(commonName, nil, x509.ExtKeyUsageClientAuth, time.Now().Add(-time.Hour))
+}
+
+func (ca *CA) issue(commonName string, hosts []string, usage x509.ExtKeyUsage, notBefore time.Time) tls.Certificate {
+	key := newKey()
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(atomic.AddInt64(&ca.serial, 1)),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    notBefore,
+		NotAfter:     notBefore.Add(certValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.Cert, key.Public(), ca.Key)
+	if err != nil {
+		panic(fmt.Sprintf("issuing certificate: %v", err))
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		panic(fmt.Sprintf("issuing certificate: %v", err))
+	}
+	return tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+		Leaf:        leaf,
+	}
+}
+
+// TLSOptions selects the variant of the configs returned by
+// CA.TLSConfigs.
+type TLSOptions struct {
+	// Mutual makes the server require a client certificate issued
+	// by the CA, and gives the client one.
+	Mutual bool
+
+	// Expired gives the server an expired certificate.
+	Expired bool
+
+	// WrongHost gives the server a certificate for a host other than
+	// the one clients connect to.
+	WrongHost bool
+}
+
+// TLSConfigs returns matching server and client TLS configs, suitable
+// for Server.TLS (used by StartTLS) and rfc5424.TLSDialFunc. The
+// client trusts only the CA. With the Expired and WrongHost options
+// the client is expected to reject the server.
+func (ca *CA) TLSConfigs(opts TLSOptions) (server, client *tls.Config) {
+	hosts := DefaultHosts
+	if opts.WrongHost {
+		hosts = []string{"wrong.invalid"}
+	}
+	serverCert := ca.ServerCert(hosts...)
+	if opts.Expired {
+		serverCert = ca.ExpiredServerCert(hosts...)
+	}
+
+	server = &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+	}
+	client = &tls.Config{
+		RootCAs: ca.Pool,
+	}
+	if opts.Mutual {
+		server.ClientAuth = tls.RequireAndVerifyClientCert
+		server.ClientCAs = ca.Pool
+		client.Certificates = []tls.Certificate{ca.ClientCert("rfc5424test client")}
+	}
+	return server, client
+}
+
+// NewTLSConfigs is a shortcut for creating a new CA and calling its
+// TLSConfigs method.
+func NewTLSConfigs(opts TLSOptions) (server, client *tls.Config) {
+	return NewCA().TLSConfigs(opts)
+}
+
+func newKey() *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(fmt.Sprintf("generating key: %v", err))
+	}
+	return key
+}
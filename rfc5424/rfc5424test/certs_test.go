@@ -0,0 +1,94 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package rfc5424test_test
+
+import (
+	"crypto/x509"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/rfc/v2/rfc5424"
+	"github.com/juju/rfc/v2/rfc5424/rfc5424test"
+)
+
+type CertsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&CertsSuite{})
+
+func (s *CertsSuite) send(c *gc.C, opts rfc5424test.TLSOptions) (*rfc5424test.Recorder, error) {
+	recorder := rfc5424test.NewRecorder()
+	server := rfc5424test.NewServer(recorder)
+	serverTLS, clientTLS := rfc5424test.NewTLSConfigs(opts)
+	server.TLS = serverTLS
+	server.StartTLS()
+	s.AddCleanup(func(*gc.C) { server.Close() })
+
+	dial, err := rfc5424.TLSDialFunc(clientTLS, 10*time.Second)
+	c.Assert(err, jc.ErrorIsNil)
+	client, err := rfc5424.Open(server.Addr().String(), rfc5424.ClientConfig{}, dial)
+	if err != nil {
+		return recorder, err
+	}
+	defer client.Close()
+	return recorder, client.Send(newMessage())
+}
+
+func (s *CertsSuite) TestTLSConfigs(c *gc.C) {
+	recorder, err := s.send(c, rfc5424test.TLSOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = recorder.WaitForN(1, 10*time.Second)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CertsSuite) TestTLSConfigsMutual(c *gc.C) {
+	recorder, err := s.send(c, rfc5424test.TLSOptions{Mutual: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = recorder.WaitForN(1, 10*time.Second)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CertsSuite) TestTLSConfigsExpired(c *gc.C) {
+	_, err := s.send(c, rfc5424test.TLSOptions{Expired: true})
+	c.Check(err, gc.ErrorMatches, "dialing TLS: .*certificate has expired.*")
+}
+
+func (s *CertsSuite) TestTLSConfigsWrongHost(c *gc.C) {
+	_, err := s.send(c, rfc5424test.TLSOptions{WrongHost: true})
+	c.Check(err, gc.ErrorMatches, "dialing TLS: .*x509: cannot validate certificate for 127.0.0.1.*")
+}
+
+func (s *CertsSuite) TestServerCert(c *gc.C) {
+	ca := rfc5424test.NewCA()
+
+	cert := ca.ServerCert()
+
+	c.Check(cert.Leaf.DNSNames, jc.DeepEquals, []string{"localhost"})
+	c.Assert(cert.Leaf.IPAddresses, gc.HasLen, 1)
+	c.Check(cert.Leaf.IPAddresses[0].String(), gc.Equals, "127.0.0.1")
+	_, err := cert.Leaf.Verify(x509.VerifyOptions{
+		DNSName: "localhost",
+		Roots:   ca.Pool,
+	})
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *CertsSuite) TestClientCert(c *gc.C) {
+	ca := rfc5424test.NewCA()
+
+	cert := ca.ClientCert("agent-1")
+
+	c.Check(cert.Leaf.Subject.CommonName, gc.Equals, "agent-1")
+	_, err := cert.Leaf.Verify(x509.VerifyOptions{
+		Roots:     ca.Pool,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	c.Check(err, jc.ErrorIsNil)
+}
@@ -0,0 +1,148 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package rfc5424test
+
+import (
+	"fmt"
+
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/rfc/v2/rfc5424"
+)
+
+// CheckSeverity returns an error unless the message was parsed and
+// has the given severity. It is suitable for use with testing.T.
+func CheckSeverity(m Message, severity rfc5424.Severity) error {
+	if m.ParseError != nil {
+		return fmt.Errorf("message %q not parsed: %v", m.Message, m.ParseError)
+	}
+	return checkSeverity(m.Parsed, severity)
+}
+
+// CheckSDParam returns an error unless the message was parsed and has
+// a structured data element with the given ID that has a param with
+// the given name and value. It is suitable for use with testing.T.
+func CheckSDParam(m Message, id rfc5424.StructuredDataName, name rfc5424.StructuredDataName, value string) error {
+	if m.ParseError != nil {
+		return fmt.Errorf("message %q not parsed: %v", m.Message, m.ParseError)
+	}
+	return checkSDParam(m.Parsed, id, name, value)
+}
+
+// WithSeverity returns a predicate, for use with Recorder.WaitFor,
+// that matches messages with the given severity.
+func WithSeverity(severity rfc5424.Severity) func(Message) bool {
+	return func(m Message) bool {
+		return CheckSeverity(m, severity) == nil
+	}
+}
+
+// WithSDParam returns a predicate, for use with Recorder.WaitFor,
+// that matches messages with the given structured data param.
+func WithSDParam(id rfc5424.StructuredDataName, name rfc5424.StructuredDataName, value string) func(Message) bool {
+	return func(m Message) bool {
+		return CheckSDParam(m, id, name, value) == nil
+	}
+}
+
+func checkSeverity(m rfc5424.Message, severity rfc5424.Severity) error {
+	if m.Severity != severity {
+		return fmt.Errorf("severity is %v, not %v", m.Severity, severity)
+	}
+	return nil
+}
+
+func checkSDParam(m rfc5424.Message, id rfc5424.StructuredDataName, name rfc5424.StructuredDataName, value string) error {
+	found := false
+	for _, elem := range m.StructuredData {
+		if elem.ID() != id {
+			continue
+		}
+		found = true
+		for _, param := range elem.Params() {
+			if param.Name == name && string(param.Value) == value {
+				return nil
+			}
+		}
+	}
+	if !found {
+		return fmt.Errorf("no structured data element %q", id)
+	}
+	return fmt.Errorf("no param %s=%q in structured data element %q", name, value, id)
+}
+
+// HasSeverity is a gocheck checker that verifies that the obtained
+// Message or rfc5424.Message has the expected severity.
+//
+//	c.Check(msg, rfc5424test.HasSeverity, rfc5424.SeverityError)
+var HasSeverity gc.Checker = &messageChecker{
+	CheckerInfo: &gc.CheckerInfo{Name: "HasSeverity", Params: []string{"obtained", "severity"}},
+	check: func(m rfc5424.Message, params []interface{}) error {
+		severity, ok := params[0].(rfc5424.Severity)
+		if !ok {
+			return fmt.Errorf("severity must be an rfc5424.Severity")
+		}
+		return checkSeverity(m, severity)
+	},
+}
+
+// HasSDParam is a gocheck checker that verifies that the obtained
+// Message or rfc5424.Message has a structured data element with the
+// given ID, that in turn has a param with the given name and value.
+//
+//	c.Check(msg, rfc5424test.HasSDParam, "origin", "software", "juju")
+var HasSDParam gc.Checker = &messageChecker{
+	CheckerInfo: &gc.CheckerInfo{Name: "HasSDParam", Params: []string{"obtained", "id", "name", "value"}},
+	check: func(m rfc5424.Message, params []interface{}) error {
+		var strs [3]string
+		for i, param := range params {
+			str, ok := stringParam(param)
+			if !ok {
+				return fmt.Errorf("%v must be a string", param)
+			}
+			strs[i] = str
+		}
+		id := rfc5424.StructuredDataName(strs[0])
+		name := rfc5424.StructuredDataName(strs[1])
+		return checkSDParam(m, id, name, strs[2])
+	},
+}
+
+type messageChecker struct {
+	*gc.CheckerInfo
+	check func(rfc5424.Message, []interface{}) error
+}
+
+// Check implements gc.Checker.
+func (checker *messageChecker) Check(params []interface{}, names []string) (bool, string) {
+	var m rfc5424.Message
+	switch obtained := params[0].(type) {
+	case Message:
+		if obtained.ParseError != nil {
+			return false, fmt.Sprintf("message not parsed: %v", obtained.ParseError)
+		}
+		m = obtained.Parsed
+	case rfc5424.Message:
+		m = obtained
+	default:
+		return false, "obtained value must be an rfc5424test.Message or rfc5424.Message"
+	}
+	if err := checker.check(m, params[1:]); err != nil {
+		return false, err.Error()
+	}
+	return true, ""
+}
+
+func stringParam(param interface{}) (string, bool) {
+	switch param := param.(type) {
+	case string:
+		return param, true
+	case rfc5424.StructuredDataName:
+		return string(param), true
+	case rfc5424.StructuredDataParamValue:
+		return string(param), true
+	default:
+		return "", false
+	}
+}
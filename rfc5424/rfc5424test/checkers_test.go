@@ -0,0 +1,66 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package rfc5424test_test
+
+import (
+	"fmt"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/rfc/v2/rfc5424"
+	"github.com/juju/rfc/v2/rfc5424/rfc5424test"
+)
+
+type CheckersSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&CheckersSuite{})
+
+func (s *CheckersSuite) TestHasSeverity(c *gc.C) {
+	msg := newMessage()
+
+	c.Check(msg, rfc5424test.HasSeverity, rfc5424.SeverityWarning)
+	c.Check(rfc5424test.Message{Parsed: msg}, rfc5424test.HasSeverity, rfc5424.SeverityWarning)
+
+	ok, reason := rfc5424test.HasSeverity.Check([]interface{}{msg, rfc5424.SeverityError}, nil)
+	c.Check(ok, jc.IsFalse)
+	c.Check(reason, gc.Equals, "severity is WARNING, not ERROR")
+}
+
+func (s *CheckersSuite) TestHasSDParam(c *gc.C) {
+	msg := newMessage()
+
+	c.Check(msg, rfc5424test.HasSDParam, "sde0", "abc", "123")
+
+	ok, reason := rfc5424test.HasSDParam.Check([]interface{}{msg, "sde0", "abc", "999"}, nil)
+	c.Check(ok, jc.IsFalse)
+	c.Check(reason, gc.Equals, `no param abc="999" in structured data element "sde0"`)
+
+	ok, reason = rfc5424test.HasSDParam.Check([]interface{}{msg, "sde9", "abc", "123"}, nil)
+	c.Check(ok, jc.IsFalse)
+	c.Check(reason, gc.Equals, `no structured data element "sde9"`)
+}
+
+func (s *CheckersSuite) TestCheckerUnparsed(c *gc.C) {
+	m := rfc5424test.Message{Message: "junk", ParseError: fmt.Errorf("bad")}
+
+	ok, reason := rfc5424test.HasSeverity.Check([]interface{}{m, rfc5424.SeverityError}, nil)
+	c.Check(ok, jc.IsFalse)
+	c.Check(reason, gc.Equals, "message not parsed: bad")
+
+	err := rfc5424test.CheckSDParam(m, "sde0", "abc", "123")
+	c.Check(err, gc.ErrorMatches, `message "junk" not parsed: bad`)
+}
+
+func (s *CheckersSuite) TestCheckFuncs(c *gc.C) {
+	m := rfc5424test.Message{Parsed: newMessage()}
+
+	c.Check(rfc5424test.CheckSeverity(m, rfc5424.SeverityWarning), jc.ErrorIsNil)
+	c.Check(rfc5424test.CheckSDParam(m, "sde0", "def", "456"), jc.ErrorIsNil)
+	c.Check(rfc5424test.WithSDParam("sde0", "def", "456")(m), jc.IsTrue)
+	c.Check(rfc5424test.WithSeverity(rfc5424.SeverityDebug)(m), jc.IsFalse)
+}
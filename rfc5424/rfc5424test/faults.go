@@ -0,0 +1,85 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package rfc5424test
+
+import (
+	"time"
+)
+
+// Faults describes the ways in which a Server should misbehave, so
+// that the reconnect and retry paths of clients can be tested. The
+// zero value means the server behaves normally.
+type Faults struct {
+	// DropAfter, if positive, makes the server close each connection
+	// once that many messages have been received on it. Any further
+	// data the client already sent is discarded, and for RELP none
+	// of it is acknowledged.
+	DropAfter int
+
+	// StallReads makes the server stop reading from connections (and
+	// UDP) until the fault is cleared. For RELP, messages are not
+	// acknowledged in the meantime. Once the kernel buffers fill,
+	// client writes block, which triggers their write deadlines.
+	StallReads bool
+
+	// RefuseFor makes the server close every new connection as soon
+	// as it is accepted, for this long after SetFaults is called.
+	RefuseFor time.Duration
+
+	// AbortTLSHandshake makes the server close new TLS connections
+	// without completing the handshake.
+	AbortTLSHandshake bool
+
+	// PartialFrames makes the server deliver the data it received of
+	// an incomplete octet-counted frame, when the connection ends part
+	// way through one, rather than discarding it. Such messages are
+	// marked as Partial.
+	PartialFrames bool
+}
+
+// SetFaults replaces the faults the server injects. It may be called
+// at any time, including while the server is running, and affects
+// existing connections as well as new ones.
+func (s *Server) SetFaults(faults Faults) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = faults
+	s.refuseUntil = time.Time{}
+	if faults.RefuseFor > 0 {
+		s.refuseUntil = time.Now().Add(faults.RefuseFor)
+	}
+	switch {
+	case faults.StallReads && s.unstalled == nil:
+		s.unstalled = make(chan struct{})
+	case !faults.StallReads && s.unstalled != nil:
+		close(s.unstalled)
+		s.unstalled = nil
+	}
+}
+
+func (s *Server) currentFaults() Faults {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.faults
+}
+
+func (s *Server) refusing() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return time.Now().Before(s.refuseUntil)
+}
+
+// waitWhileStalled blocks while reads are stalled. It returns false
+// if the server was closed in the meantime.
+func (s *Server) waitWhileStalled() bool {
+	for {
+		s.mu.Lock()
+		unstalled, closed := s.unstalled, s.closed
+		s.mu.Unlock()
+		if unstalled == nil {
+			return !closed
+		}
+		<-unstalled
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package rfc5424test_test
+
+import (
+	"crypto/tls"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/rfc/v2/rfc5424"
+	"github.com/juju/rfc/v2/rfc5424/rfc5424test"
+)
+
+type FaultsSuite struct {
+	testing.IsolationSuite
+
+	recorder *rfc5424test.Recorder
+	server   *rfc5424test.Server
+}
+
+var _ = gc.Suite(&FaultsSuite{})
+
+func (s *FaultsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.recorder = rfc5424test.NewRecorder()
+	s.server = rfc5424test.NewServer(s.recorder)
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+}
+
+func (s *FaultsSuite) dial(c *gc.C) net.Conn {
+	conn, err := net.Dial("tcp", s.server.Addr().String())
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { conn.Close() })
+	return conn
+}
+
+func (s *FaultsSuite) TestDropAfter(c *gc.C) {
+	s.server.SetFaults(rfc5424test.Faults{DropAfter: 2})
+	s.server.Start()
+
+	conn := s.dial(c)
+	line := newMessage().String() + "\n"
+	_, err := conn.Write([]byte(line + line + line))
+	c.Assert(err, jc.ErrorIsNil)
+
+	assertClosedByServer(c, conn)
+	c.Check(s.recorder.Messages(), gc.HasLen, 2)
+}
+
+func (s *FaultsSuite) TestStallReads(c *gc.C) {
+	s.server.SetFaults(rfc5424test.Faults{StallReads: true})
+	s.server.Start()
+
+	cfg := rfc5424.ClientConfig{SendTimeout: 50 * time.Millisecond}
+	client, err := rfc5424.Open(s.server.Addr().String(), cfg, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	defer client.Close()
+
+	msg := newMessage()
+	msg.Msg = strings.Repeat("x", 64*1024)
+	sent := 0
+	for ; sent < 10000; sent++ {
+		if err = client.Send(msg); err != nil {
+			break
+		}
+	}
+	c.Assert(err, gc.ErrorMatches, ".*i/o timeout")
+	c.Check(s.recorder.Messages(), gc.HasLen, 0)
+
+	s.server.SetFaults(rfc5424test.Faults{})
+	_, err = s.recorder.WaitForN(sent, 10*time.Second)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *FaultsSuite) TestRefuseFor(c *gc.C) {
+	s.server.SetFaults(rfc5424test.Faults{RefuseFor: time.Hour})
+	s.server.Start()
+
+	assertClosedByServer(c, s.dial(c))
+
+	s.server.SetFaults(rfc5424test.Faults{})
+	conn := s.dial(c)
+	_, err := conn.Write([]byte(newMessage().String() + "\n"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.recorder.WaitForN(1, 10*time.Second)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *FaultsSuite) TestAbortTLSHandshake(c *gc.C) {
+	serverTLS, clientTLS := rfc5424test.NewTLSConfigs(rfc5424test.TLSOptions{})
+	s.server.TLS = serverTLS
+	s.server.SetFaults(rfc5424test.Faults{AbortTLSHandshake: true})
+	s.server.StartTLS()
+
+	_, err := tls.Dial("tcp", s.server.Addr().String(), clientTLS)
+	c.Assert(err, gc.NotNil)
+}
+
+func (s *FaultsSuite) TestPartialFrames(c *gc.C) {
+	s.server.Start()
+	conn := s.dial(c)
+	_, err := conn.Write([]byte("100 <8>1 - - - - - -"))
+	c.Assert(err, jc.ErrorIsNil)
+	conn.Close()
+	assertNoMessages(c, s.recorder)
+
+	s.server.SetFaults(rfc5424test.Faults{PartialFrames: true})
+	conn = s.dial(c)
+	_, err = conn.Write([]byte("100 <8>1 - - - - - -"))
+	c.Assert(err, jc.ErrorIsNil)
+	conn.Close()
+
+	messages, err := s.recorder.WaitForN(1, 10*time.Second)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(messages[0].Partial, jc.IsTrue)
+	c.Check(messages[0].Framing, gc.Equals, rfc5424.FramingOctetCounting)
+	c.Check(messages[0].Message, gc.Equals, "<8>1 - - - - - -")
+}
+
+func (s *FaultsSuite) TestFinalLineWithoutLF(c *gc.C) {
+	s.server.Start()
+	conn := s.dial(c)
+	_, err := conn.Write([]byte("<8>1 - - - - - -"))
+	c.Assert(err, jc.ErrorIsNil)
+	conn.Close()
+
+	// Without a LF, the line is still complete, so it is delivered
+	// without PartialFrames and is not marked as partial.
+	messages, err := s.recorder.WaitForN(1, 10*time.Second)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(messages[0].Partial, jc.IsFalse)
+	c.Check(messages[0].Framing, gc.Equals, rfc5424.FramingNonTransparent)
+	c.Check(messages[0].Message, gc.Equals, "<8>1 - - - - - -")
+}
+
+func assertClosedByServer(c *gc.C, conn net.Conn) {
+	conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+	_, err := conn.Read(make([]byte, 1))
+	if netErr, ok := err.(net.Error); ok {
+		c.Assert(netErr.Timeout(), jc.IsFalse)
+	} else {
+		c.Assert(err, gc.Equals, io.EOF)
+	}
+}
+
+func assertNoMessages(c *gc.C, recorder *rfc5424test.Recorder) {
+	messages, err := recorder.WaitForN(1, 50*time.Millisecond)
+	c.Assert(err, gc.NotNil)
+	c.Assert(messages, gc.HasLen, 0)
+}
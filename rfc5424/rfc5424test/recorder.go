@@ -0,0 +1,103 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package rfc5424test
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Recorder is a Handler that records every message it receives so
+// that tests can wait for and inspect them.
+type Recorder struct {
+	mu       sync.Mutex
+	messages []Message
+	changed  chan struct{} // closed when messages changes
+}
+
+// NewRecorder returns a new Recorder with no messages.
+func NewRecorder() *Recorder {
+	return &Recorder{
+		changed: make(chan struct{}),
+	}
+}
+
+// HandleSyslog records the message.
+func (r *Recorder) HandleSyslog(m Message) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.messages = append(r.messages, m)
+	r.notifyLocked()
+}
+
+// Messages returns the messages recorded so far, in the order they
+// were received.
+func (r *Recorder) Messages() []Message {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	messages := make([]Message, len(r.messages))
+	copy(messages, r.messages)
+	return messages
+}
+
+// Reset discards all the recorded messages.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.messages = nil
+	r.notifyLocked()
+}
+
+// WaitForN waits until at least n messages have been recorded and
+// returns the first n of them. If that does not happen within the
+// timeout then the messages recorded so far are returned along with
+// an error.
+func (r *Recorder) WaitForN(n int, timeout time.Duration) ([]Message, error) {
+	deadline := time.After(timeout)
+	for {
+		messages, changed := r.snapshot()
+		if len(messages) >= n {
+			return messages[:n], nil
+		}
+		select {
+		case <-changed:
+		case <-deadline:
+			return messages, fmt.Errorf("timed out waiting for %d messages (got %d)", n, len(messages))
+		}
+	}
+}
+
+// WaitFor waits until a message that satisfies the predicate has been
+// recorded and returns the first such message. Messages recorded
+// before the call are considered too.
+func (r *Recorder) WaitFor(match func(Message) bool, timeout time.Duration) (Message, error) {
+	deadline := time.After(timeout)
+	for {
+		messages, changed := r.snapshot()
+		for _, m := range messages {
+			if match(m) {
+				return m, nil
+			}
+		}
+		select {
+		case <-changed:
+		case <-deadline:
+			return Message{}, fmt.Errorf("timed out waiting for matching message (checked %d)", len(messages))
+		}
+	}
+}
+
+func (r *Recorder) snapshot() ([]Message, <-chan struct{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	messages := make([]Message, len(r.messages))
+	copy(messages, r.messages)
+	return messages, r.changed
+}
+
+func (r *Recorder) notifyLocked() {
+	close(r.changed)
+	r.changed = make(chan struct{})
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package rfc5424test_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/rfc/v2/rfc5424"
+	"github.com/juju/rfc/v2/rfc5424/rfc5424test"
+)
+
+type RecorderSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&RecorderSuite{})
+
+func (s *RecorderSuite) TestWaitForN(c *gc.C) {
+	recorder := rfc5424test.NewRecorder()
+	server := rfc5424test.NewServer(recorder)
+	server.Start()
+	defer server.Close()
+
+	client, err := rfc5424.Open(server.Addr().String(), rfc5424.ClientConfig{}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	defer client.Close()
+	for _, text := range []string{"one", "two", "three"} {
+		msg := newMessage()
+		msg.Msg = text
+		err := client.Send(msg)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	messages, err := recorder.WaitForN(2, 10*time.Second)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(messages, gc.HasLen, 2)
+	c.Check(messages[0].Parsed.Msg, gc.Equals, "one")
+	c.Check(messages[1].Parsed.Msg, gc.Equals, "two")
+
+	_, err = recorder.WaitForN(3, 10*time.Second)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(recorder.Messages(), gc.HasLen, 3)
+}
+
+func (s *RecorderSuite) TestWaitForNTimeout(c *gc.C) {
+	recorder := rfc5424test.NewRecorder()
+	recorder.HandleSyslog(rfc5424test.Message{Message: "one"})
+
+	messages, err := recorder.WaitForN(2, time.Millisecond)
+	c.Check(err, gc.ErrorMatches, `timed out waiting for 2 messages \(got 1\)`)
+	c.Check(messages, gc.HasLen, 1)
+}
+
+func (s *RecorderSuite) TestWaitFor(c *gc.C) {
+	recorder := rfc5424test.NewRecorder()
+	go func() {
+		for _, severity := range []rfc5424.Severity{rfc5424.SeverityDebug, rfc5424.SeverityError} {
+			msg := newMessage()
+			msg.Severity = severity
+			recorder.HandleSyslog(rfc5424test.Message{Message: msg.String(), Parsed: msg})
+		}
+	}()
+
+	msg, err := recorder.WaitFor(rfc5424test.WithSeverity(rfc5424.SeverityError), 10*time.Second)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(msg.Parsed.Severity, gc.Equals, rfc5424.SeverityError)
+}
+
+func (s *RecorderSuite) TestWaitForTimeout(c *gc.C) {
+	recorder := rfc5424test.NewRecorder()
+	recorder.HandleSyslog(rfc5424test.Message{Parsed: newMessage()})
+
+	_, err := recorder.WaitFor(rfc5424test.WithSeverity(rfc5424.SeverityError), time.Millisecond)
+	c.Check(err, gc.ErrorMatches, `timed out waiting for matching message \(checked 1\)`)
+}
+
+func (s *RecorderSuite) TestReset(c *gc.C) {
+	recorder := rfc5424test.NewRecorder()
+	recorder.HandleSyslog(rfc5424test.Message{Message: "one"})
+
+	recorder.Reset()
+
+	c.Check(recorder.Messages(), gc.HasLen, 0)
+	recorder.HandleSyslog(rfc5424test.Message{Message: "two"})
+	messages, err := recorder.WaitForN(1, time.Millisecond)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(messages[0].Message, gc.Equals, "two")
+}
@@ -4,13 +4,27 @@
 package rfc5424test
 
 import (
-	"bufio"
+	"bytes"
 	"crypto/tls"
+	"errors"
 	"fmt"
+	"io"
 	"net"
 	"sync"
+	"time"
+
+	"github.com/juju/rfc/v2/rfc5424"
+	"github.com/juju/rfc/v2/rfc5424/relp"
 )
 
+// maxDatagramSize is the size of the buffer used to read UDP
+// datagrams, which is the largest UDP payload.
+const maxDatagramSize = 65507
+
+// errDropped is returned to a RELP client for a message that the
+// server dropped, though the connection is closed before it can be.
+var errDropped = errors.New("message dropped")
+
 // Handler defines an interface for handling RFC5424 messages.
 type Handler interface {
 	HandleSyslog(Message Message)
@@ -27,19 +41,42 @@ func (f HandlerFunc) HandleSyslog(m Message) {
 type Message struct {
 	RemoteAddr string
 	Message    string
+
+	// Parsed holds the result of parsing Message, if that succeeded.
+	Parsed rfc5424.Message
+
+	// ParseError holds the reason Message could not be parsed, if
+	// it could not.
+	ParseError error
+
+	// Framing is the framing the message was received with. It is
+	// always FramingNonTransparent for UDP and FramingOctetCounting
+	// for RELP, whose frames carry the length of the message.
+	Framing rfc5424.Framing
+
+	// Partial is set if the connection ended before the message was
+	// complete. See Faults.PartialFrames.
+	Partial bool
 }
 
 // Server is a server for testing the receipt of RFC5424 messages.
 type Server struct {
-	Listener net.Listener
-	TLS      *tls.Config
-	handler  Handler
+	Listener   net.Listener
+	PacketConn net.PacketConn // set instead of Listener for UDP
+	TLS        *tls.Config
+	handler    Handler
+	relp       bool
 
 	mu       sync.Mutex
 	wg       sync.WaitGroup
 	listener net.Listener // Listener, or Listener wrapped with TLS
 	closed   bool
+	started  bool // set when serving PacketConn
 	conns    []net.Conn
+
+	faults      Faults
+	refuseUntil time.Time
+	unstalled   chan struct{} // non-nil while reads are stalled
 }
 
 // NewServer creates a new Server which will invoke the given Handler
@@ -57,6 +94,34 @@ func NewServer(handler Handler) *Server {
 	return &Server{Listener: l, handler: handler}
 }
 
+// NewUDPServer is like NewServer except that the server receives
+// messages over UDP. The listening address can be obtained by
+// inspecting the Server's PacketConn field.
+func NewUDPServer(handler Handler) *Server {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		panic(fmt.Sprintf("NewUDPServer: %v", err))
+	}
+	return &Server{PacketConn: pc, handler: handler}
+}
+
+// NewRELPServer is like NewServer except that the server receives
+// messages over RELP. Each message is acknowledged once the handler
+// has returned.
+func NewRELPServer(handler Handler) *Server {
+	s := NewServer(handler)
+	s.relp = true
+	return s
+}
+
+// Addr returns the address the server is listening on.
+func (s *Server) Addr() net.Addr {
+	if s.PacketConn != nil {
+		return s.PacketConn.LocalAddr()
+	}
+	return s.Listener.Addr()
+}
+
 // Start starts the server listening for client connections.
 func (s *Server) Start() {
 	s.mu.Lock()
@@ -64,9 +129,14 @@ func (s *Server) Start() {
 	if s.closed {
 		panic("Start: server already closed")
 	}
-	if s.listener != nil {
+	if s.listener != nil || s.started {
 		panic("Start: server already started")
 	}
+	if s.PacketConn != nil {
+		s.started = true
+		s.goServePackets()
+		return
+	}
 	s.listener = s.Listener
 	s.goServe()
 }
@@ -79,9 +149,12 @@ func (s *Server) StartTLS() {
 	if s.closed {
 		panic("StartTLS: server already closed")
 	}
-	if s.listener != nil {
+	if s.listener != nil || s.started {
 		panic("StartTLS: server already started")
 	}
+	if s.PacketConn != nil {
+		panic("StartTLS: TLS is not supported over UDP")
+	}
 	if s.TLS == nil || len(s.TLS.Certificates) == 0 {
 		panic("no certificates specified")
 	}
@@ -103,6 +176,13 @@ func (s *Server) Close() {
 				conn.Close()
 			}
 		}
+		if s.PacketConn != nil {
+			s.PacketConn.Close()
+		}
+		if s.unstalled != nil {
+			close(s.unstalled)
+			s.unstalled = nil
+		}
 	}
 }
 
@@ -121,6 +201,10 @@ func (s *Server) serve() {
 		if err != nil {
 			return
 		}
+		if s.refusing() {
+			conn.Close()
+			continue
+		}
 		s.wg.Add(1)
 		go func() {
 			defer s.wg.Done()
@@ -136,14 +220,95 @@ func (s *Server) serve() {
 }
 
 func (s *Server) serveConn(conn net.Conn) {
+	if _, ok := conn.(*tls.Conn); ok && s.currentFaults().AbortTLSHandshake {
+		return
+	}
+
 	remoteAddr := conn.RemoteAddr().String()
-	scanner := bufio.NewScanner(conn)
-	for scanner.Scan() {
-		text := scanner.Text()
-		message := Message{
-			RemoteAddr: remoteAddr,
-			Message:    text,
+	if s.relp {
+		s.serveRELPConn(conn, remoteAddr)
+		return
+	}
+	reader := rfc5424.NewFrameReader(conn, 0)
+	for count := 0; ; count++ {
+		if dropAfter := s.currentFaults().DropAfter; dropAfter > 0 && count >= dropAfter {
+			return
+		}
+		if !s.waitWhileStalled() {
+			return
 		}
-		s.handler.HandleSyslog(message)
+		frame, framing, err := reader.ReadFrame()
+		partial := err == io.ErrUnexpectedEOF
+		switch {
+		case err == nil:
+			// Empty lines are delivered too, and fail to parse.
+			s.handle(remoteAddr, frame, framing, false)
+		case len(frame) == 0:
+		case partial && framing == rfc5424.FramingNonTransparent:
+			// A final line without a trailing LF still counts, as
+			// a complete message.
+			s.handle(remoteAddr, frame, framing, false)
+		case partial && s.currentFaults().PartialFrames:
+			s.handle(remoteAddr, frame, framing, true)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (s *Server) serveRELPConn(conn net.Conn, remoteAddr string) {
+	count := 0
+	relp.ServeConn(conn, 0, func(data []byte) error {
+		if dropAfter := s.currentFaults().DropAfter; dropAfter > 0 && count >= dropAfter {
+			conn.Close()
+			return errDropped
+		}
+		if !s.waitWhileStalled() {
+			conn.Close()
+			return errDropped
+		}
+		count++
+		s.handle(remoteAddr, data, rfc5424.FramingOctetCounting, false)
+		return nil
+	})
+}
+
+func (s *Server) goServePackets() {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.servePackets()
+	}()
+}
+
+func (s *Server) servePackets() {
+	buf := make([]byte, maxDatagramSize)
+	for {
+		if !s.waitWhileStalled() {
+			return
+		}
+		n, addr, err := s.PacketConn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		frame := bytes.TrimRight(buf[:n], "\r\n\x00")
+		s.handle(addr.String(), frame, rfc5424.FramingNonTransparent, false)
+	}
+}
+
+func (s *Server) handle(remoteAddr string, frame []byte, framing rfc5424.Framing, partial bool) {
+	message := Message{
+		RemoteAddr: remoteAddr,
+		Message:    string(frame),
+		Framing:    framing,
+		Partial:    partial,
+	}
+	parsed, err := rfc5424.ParseMessage(message.Message)
+	if err != nil {
+		message.ParseError = err
+	} else {
+		message.Parsed = parsed
 	}
+	s.handler.HandleSyslog(message)
 }
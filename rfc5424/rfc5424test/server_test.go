@@ -12,6 +12,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/rfc/v2/rfc5424"
+	"github.com/juju/rfc/v2/rfc5424/relp"
 	"github.com/juju/rfc/v2/rfc5424/rfc5424test"
 )
 
@@ -52,7 +53,7 @@ func (s *ServerSuite) TestSend(c *gc.C) {
 				Severity: rfc5424.SeverityWarning,
 				Facility: rfc5424.FacilityDaemon,
 			},
-			Timestamp: rfc5424.Timestamp{time.Unix(54321, 123).UTC()},
+			Timestamp: rfc5424.Timestamp{Time: time.Unix(54321, 123).UTC()},
 			Hostname:  rfc5424.Hostname{FQDN: "a.b.org"},
 			AppName:   "an-app",
 			ProcID:    "119",
@@ -80,6 +81,169 @@ func (s *ServerSuite) TestSend(c *gc.C) {
 	}
 }
 
+func (s *ServerSuite) TestSendOctetCounting(c *gc.C) {
+	received := make(chan rfc5424test.Message, 1)
+	server := rfc5424test.NewServer(rfc5424test.HandlerFunc(func(msg rfc5424test.Message) {
+		received <- msg
+	}))
+	server.Start()
+	defer server.Close()
+
+	cfg := rfc5424.ClientConfig{
+		Framing: rfc5424.FramingOctetCounting,
+	}
+	client, err := rfc5424.Open(server.Addr().String(), cfg, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	defer client.Close()
+
+	msg := newMessage()
+	msg.Msg = "a message\nover two lines"
+	err = client.Send(msg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case got := <-received:
+		c.Check(got.Framing, gc.Equals, rfc5424.FramingOctetCounting)
+		c.Check(got.Message, gc.Equals, msg.String())
+		c.Assert(got.ParseError, jc.ErrorIsNil)
+		c.Check(got.Parsed.Msg, gc.Equals, "a message\nover two lines")
+	case <-time.After(10 * time.Second):
+		c.Fatal("timed out waiting for message")
+	}
+}
+
+func (s *ServerSuite) TestSendRELP(c *gc.C) {
+	received := make(chan rfc5424test.Message, 1)
+	server := rfc5424test.NewRELPServer(rfc5424test.HandlerFunc(func(msg rfc5424test.Message) {
+		received <- msg
+	}))
+	server.Start()
+	defer server.Close()
+
+	client, err := relp.Open(server.Addr().String(), relp.ClientConfig{}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	msg := newMessage()
+	err = client.Send(msg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(client.Close(), jc.ErrorIsNil)
+
+	select {
+	case got := <-received:
+		c.Check(got.Framing, gc.Equals, rfc5424.FramingOctetCounting)
+		c.Check(got.Message, gc.Equals, msg.String())
+		c.Check(got.ParseError, jc.ErrorIsNil)
+	case <-time.After(10 * time.Second):
+		c.Fatal("timed out waiting for message")
+	}
+}
+
+func (s *ServerSuite) TestSendUDP(c *gc.C) {
+	received := make(chan rfc5424test.Message, 1)
+	server := rfc5424test.NewUDPServer(rfc5424test.HandlerFunc(func(msg rfc5424test.Message) {
+		received <- msg
+	}))
+	server.Start()
+	defer server.Close()
+
+	udpDial := func(_, address string) (rfc5424.Conn, error) {
+		return net.Dial("udp", address)
+	}
+	client, err := rfc5424.Open(server.Addr().String(), rfc5424.ClientConfig{}, udpDial)
+	c.Assert(err, jc.ErrorIsNil)
+	defer client.Close()
+
+	err = client.Send(newMessage())
+	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case got := <-received:
+		c.Assert(got.ParseError, jc.ErrorIsNil)
+		c.Check(got.Parsed.Header, jc.DeepEquals, newMessage().Header)
+		c.Check(got.Parsed.StructuredData, jc.DeepEquals, rfc5424.StructuredData{
+			rfc5424.GenericStructuredDataElement{
+				SDID: "sde0",
+				Data: fakeStructuredDataElement{}.Params(),
+			},
+		})
+	case <-time.After(10 * time.Second):
+		c.Fatal("timed out waiting for message")
+	}
+}
+
+func (s *ServerSuite) TestParseError(c *gc.C) {
+	received := make(chan rfc5424test.Message, 1)
+	server := rfc5424test.NewServer(rfc5424test.HandlerFunc(func(msg rfc5424test.Message) {
+		received <- msg
+	}))
+	server.Start()
+	defer server.Close()
+
+	conn, err := net.Dial("tcp", server.Addr().String())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = conn.Write([]byte("not syslog"))
+	c.Assert(err, jc.ErrorIsNil)
+	conn.Close()
+
+	select {
+	case got := <-received:
+		c.Check(got.Message, gc.Equals, "not syslog")
+		c.Check(got.ParseError, gc.ErrorMatches, "bad Header: missing Priority")
+		c.Check(got.Parsed, jc.DeepEquals, rfc5424.Message{})
+	case <-time.After(10 * time.Second):
+		c.Fatal("timed out waiting for message")
+	}
+}
+
+func (s *ServerSuite) TestEmptyLines(c *gc.C) {
+	received := make(chan rfc5424test.Message, 3)
+	server := rfc5424test.NewServer(rfc5424test.HandlerFunc(func(msg rfc5424test.Message) {
+		received <- msg
+	}))
+	server.Start()
+	defer server.Close()
+
+	conn, err := net.Dial("tcp", server.Addr().String())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = conn.Write([]byte("<8>1 - - - - - -\n\n<8>1 - - - - - -\n"))
+	c.Assert(err, jc.ErrorIsNil)
+	conn.Close()
+
+	for _, text := range []string{"<8>1 - - - - - -", "", "<8>1 - - - - - -"} {
+		select {
+		case got := <-received:
+			c.Check(got.Message, gc.Equals, text)
+			if text == "" {
+				c.Check(got.ParseError, gc.NotNil)
+			} else {
+				c.Check(got.ParseError, jc.ErrorIsNil)
+			}
+		case <-time.After(10 * time.Second):
+			c.Fatal("timed out waiting for message")
+		}
+	}
+}
+
+func newMessage() rfc5424.Message {
+	return rfc5424.Message{
+		Header: rfc5424.Header{
+			Priority: rfc5424.Priority{
+				Severity: rfc5424.SeverityWarning,
+				Facility: rfc5424.FacilityDaemon,
+			},
+			Timestamp: rfc5424.Timestamp{Time: time.Unix(54321, 123).UTC()},
+			Hostname:  rfc5424.Hostname{FQDN: "a.b.org"},
+			AppName:   "an-app",
+			ProcID:    "119",
+			MsgID:     "xyz...",
+		},
+		StructuredData: rfc5424.StructuredData{
+			fakeStructuredDataElement{"sde0"},
+		},
+		Msg: "a message",
+	}
+}
+
 type fakeStructuredDataElement struct {
 	id rfc5424.StructuredDataName
 }
@@ -90,8 +254,8 @@ func (f fakeStructuredDataElement) ID() rfc5424.StructuredDataName {
 
 func (fakeStructuredDataElement) Params() []rfc5424.StructuredDataParam {
 	return []rfc5424.StructuredDataParam{
-		{"abc", "123"},
-		{"def", "456"},
+		{Name: "abc", Value: "123"},
+		{Name: "def", Value: "456"},
 	}
 }
 
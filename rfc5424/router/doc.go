@@ -0,0 +1,31 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+// The router package dispatches syslog messages to named destinations,
+// each typically its own *rfc5424.Client with its own transport,
+// according to a list of routes. For example:
+//
+//	r, err := router.New(router.Config{
+//		Destinations: map[string]rfc5424.Sender{
+//			"siem":    siemClient,
+//			"local":   localClient,
+//			"central": centralClient,
+//		},
+//		Routes: []router.Route{{
+//			Filter:       filter.MustParse("auth,authpriv.*"),
+//			Destinations: []string{"siem"},
+//		}, {
+//			Filter:       filter.MustParse("*.=debug"),
+//			Destinations: []string{"local"},
+//		}, {
+//			Destinations: []string{"central"},
+//		}},
+//	})
+//
+// sends auth and authpriv messages to the SIEM, debug messages to the
+// local destination and every message to the central collector. A
+// route that is Final stops a matching message from going on to the
+// routes after it.
+//
+// The router owns its destinations; closing it closes them.
+package router
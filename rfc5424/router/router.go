@@ -0,0 +1,221 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package router
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/rfc/v2/rfc5424"
+)
+
+// Route selects the destinations for the messages that match it.
+type Route struct {
+	// Filter selects the messages that the route applies to, e.g. a
+	// filter from the filter package. If it is nil then the route
+	// applies to every message.
+	Filter rfc5424.Matcher
+
+	// Destinations holds the names of the destinations that matching
+	// messages are sent to.
+	Destinations []string
+
+	// Final stops any later routes from being considered for a
+	// message that matches this one.
+	Final bool
+}
+
+// Config is the configuration for a Router.
+type Config struct {
+	// Destinations maps names to the senders that messages are
+	// dispatched to. The router owns them: Router.Close closes each
+	// one that implements io.Closer.
+	Destinations map[string]rfc5424.Sender
+
+	// Routes is considered in order for each message. A message is
+	// sent to the destinations of every route that matches it, up to
+	// and including the first matching Final route, and to each
+	// destination at most once.
+	Routes []Route
+}
+
+// Validate ensures that the config is correct.
+func (cfg Config) Validate() error {
+	if len(cfg.Destinations) == 0 {
+		return errors.NotValidf("no Destinations")
+	}
+	for name, sender := range cfg.Destinations {
+		if sender == nil {
+			return errors.NotValidf("nil Destinations[%q]", name)
+		}
+	}
+	if len(cfg.Routes) == 0 {
+		return errors.NotValidf("no Routes")
+	}
+	for i, route := range cfg.Routes {
+		if len(route.Destinations) == 0 {
+			return errors.NotValidf("Routes[%d] without Destinations", i)
+		}
+		for _, name := range route.Destinations {
+			if _, ok := cfg.Destinations[name]; !ok {
+				return errors.NotValidf("Routes[%d] unknown destination %q", i, name)
+			}
+		}
+	}
+	return nil
+}
+
+// Router is an rfc5424.Sender that dispatches each message to the
+// destinations selected by its routes. It is safe for concurrent use.
+type Router struct {
+	cfg Config
+
+	// mu serialises sends, since a sender need not be safe for
+	// concurrent use.
+	mu     sync.Mutex
+	closed bool
+}
+
+var (
+	_ rfc5424.Sender    = (*Router)(nil)
+	_ rfc5424.RawSender = (*Router)(nil)
+)
+
+// New returns a new router.
+func New(cfg Config) (*Router, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Router{cfg: cfg}, nil
+}
+
+// Destinations returns the names of the destinations that the message
+// would be sent to, in the order it would be sent to them.
+func (r *Router) Destinations(msg rfc5424.Message) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, route := range r.cfg.Routes {
+		if route.Filter != nil && !route.Filter.Match(msg) {
+			continue
+		}
+		for _, name := range route.Destinations {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+		if route.Final {
+			break
+		}
+	}
+	return names
+}
+
+// Send implements rfc5424.Sender. The message is sent to every
+// selected destination, even if sending to an earlier one fails; any
+// failures are returned as a *SendError. A message that no route
+// selects is dropped without error.
+func (r *Router) Send(msg rfc5424.Message) error {
+	return r.send(r.Destinations(msg), func(dest rfc5424.Sender) error {
+		return dest.Send(msg)
+	})
+}
+
+// SendRaw implements rfc5424.RawSender. The parsed message is routed
+// as for Send, and sent as it was received to destinations that are
+// rfc5424.RawSenders.
+func (r *Router) SendRaw(raw *rfc5424.RawMessage) error {
+	return r.send(r.Destinations(raw.Message), func(dest rfc5424.Sender) error {
+		return rfc5424.SendRaw(dest, raw)
+	})
+}
+
+// send calls send for each of the named destinations.
+func (r *Router) send(names []string, send func(rfc5424.Sender) error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return errors.New("router closed")
+	}
+	var sendErr *SendError
+	for _, name := range names {
+		if err := send(r.cfg.Destinations[name]); err != nil {
+			if sendErr == nil {
+				sendErr = &SendError{Errors: make(map[string]error)}
+			}
+			sendErr.Errors[name] = errors.Trace(err)
+		}
+	}
+	if sendErr != nil {
+		return sendErr
+	}
+	return nil
+}
+
+// Close closes every destination that implements io.Closer, in order
+// of name, even if closing an earlier one fails. The first error is
+// returned. A sender that is in Destinations under more than one name
+// is closed only once.
+func (r *Router) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+
+	names := make([]string, 0, len(r.cfg.Destinations))
+	for name := range r.cfg.Destinations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var firstErr error
+	closed := make(map[io.Closer]bool)
+	for _, name := range names {
+		closer, ok := r.cfg.Destinations[name].(io.Closer)
+		if !ok {
+			continue
+		}
+		// Only comparable senders can be shared, and only they can be
+		// used as map keys.
+		if reflect.TypeOf(closer).Comparable() {
+			if closed[closer] {
+				continue
+			}
+			closed[closer] = true
+		}
+		if err := closer.Close(); err != nil && firstErr == nil {
+			firstErr = errors.Annotatef(err, "closing %q", name)
+		}
+	}
+	return firstErr
+}
+
+// SendError is returned by Router.Send when sending to any destination
+// fails.
+type SendError struct {
+	// Errors maps the name of each destination that failed to its
+	// error.
+	Errors map[string]error
+}
+
+// Error implements error.
+func (e *SendError) Error() string {
+	names := make([]string, 0, len(e.Errors))
+	for name := range e.Errors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	msgs := make([]string, len(names))
+	for i, name := range names {
+		msgs[i] = fmt.Sprintf("sending to %q: %v", name, e.Errors[name])
+	}
+	return strings.Join(msgs, "; ")
+}
@@ -0,0 +1,207 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package router_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/rfc/v2/rfc5424"
+	"github.com/juju/rfc/v2/rfc5424/filter"
+	"github.com/juju/rfc/v2/rfc5424/router"
+)
+
+type RouterSuite struct {
+	testing.IsolationSuite
+
+	siem, local, central recordingSender
+}
+
+var _ = gc.Suite(&RouterSuite{})
+
+func (s *RouterSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.siem = recordingSender{}
+	s.local = recordingSender{}
+	s.central = recordingSender{}
+}
+
+func (s *RouterSuite) config() router.Config {
+	return router.Config{
+		Destinations: map[string]rfc5424.Sender{
+			"siem":    &s.siem,
+			"local":   &s.local,
+			"central": &s.central,
+		},
+		Routes: []router.Route{{
+			Filter:       filter.MustParse("auth,authpriv.*"),
+			Destinations: []string{"siem", "central"},
+		}, {
+			Filter:       filter.MustParse("*.=debug"),
+			Destinations: []string{"local"},
+			Final:        true,
+		}, {
+			Destinations: []string{"central"},
+		}},
+	}
+}
+
+func newMessage(fac rfc5424.Facility, sev rfc5424.Severity, text string) rfc5424.Message {
+	msg := rfc5424.Message{Msg: text}
+	msg.Facility = fac
+	msg.Severity = sev
+	return msg
+}
+
+func (s *RouterSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		change func(*router.Config)
+		err    string
+	}{{
+		change: func(cfg *router.Config) { cfg.Destinations = nil },
+		err:    "no Destinations not valid",
+	}, {
+		change: func(cfg *router.Config) { cfg.Destinations["local"] = nil },
+		err:    `nil Destinations\["local"\] not valid`,
+	}, {
+		change: func(cfg *router.Config) { cfg.Routes = nil },
+		err:    "no Routes not valid",
+	}, {
+		change: func(cfg *router.Config) { cfg.Routes[1].Destinations = nil },
+		err:    `Routes\[1\] without Destinations not valid`,
+	}, {
+		change: func(cfg *router.Config) { cfg.Routes[2].Destinations = []string{"remote"} },
+		err:    `Routes\[2\] unknown destination "remote" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		cfg := s.config()
+		test.change(&cfg)
+
+		_, err := router.New(cfg)
+
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *RouterSuite) TestSend(c *gc.C) {
+	r, err := router.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, msg := range []rfc5424.Message{
+		newMessage(rfc5424.FacilityAuth, rfc5424.SeverityNotice, "login"),
+		newMessage(rfc5424.FacilityDaemon, rfc5424.SeverityDebug, "debugging"),
+		newMessage(rfc5424.FacilityDaemon, rfc5424.SeverityError, "failed"),
+		newMessage(rfc5424.FacilityAuthpriv, rfc5424.SeverityDebug, "secret"),
+	} {
+		err := r.Send(msg)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	c.Check(s.siem.texts(), jc.DeepEquals, []string{"login", "secret"})
+	c.Check(s.local.texts(), jc.DeepEquals, []string{"debugging", "secret"})
+	c.Check(s.central.texts(), jc.DeepEquals, []string{"login", "failed", "secret"})
+}
+
+func (s *RouterSuite) TestDestinations(c *gc.C) {
+	r, err := router.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+
+	names := r.Destinations(newMessage(rfc5424.FacilityAuth, rfc5424.SeverityDebug, ""))
+
+	c.Check(names, jc.DeepEquals, []string{"siem", "central", "local"})
+}
+
+func (s *RouterSuite) TestNoRouteMatches(c *gc.C) {
+	cfg := s.config()
+	cfg.Routes = cfg.Routes[:1]
+	r, err := router.New(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = r.Send(newMessage(rfc5424.FacilityMail, rfc5424.SeverityError, "dropped"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.siem.messages, gc.HasLen, 0)
+	c.Check(s.central.messages, gc.HasLen, 0)
+}
+
+func (s *RouterSuite) TestSendError(c *gc.C) {
+	s.siem.err = errors.New("boom")
+	r, err := router.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = r.Send(newMessage(rfc5424.FacilityAuth, rfc5424.SeverityNotice, "login"))
+
+	c.Check(err, gc.ErrorMatches, `sending to "siem": boom`)
+	sendErr, ok := err.(*router.SendError)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(sendErr.Errors, gc.HasLen, 1)
+	c.Check(s.central.texts(), jc.DeepEquals, []string{"login"})
+}
+
+func (s *RouterSuite) TestClose(c *gc.C) {
+	local := &closingSender{err: errors.New("boom")}
+	central := &closingSender{}
+	cfg := s.config()
+	cfg.Destinations["local"] = local
+	cfg.Destinations["central"] = central
+	r, err := router.New(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = r.Close()
+	c.Check(err, gc.ErrorMatches, `closing "local": boom`)
+	c.Check(local.closed, gc.Equals, 1)
+	c.Check(central.closed, gc.Equals, 1)
+
+	err = r.Send(newMessage(rfc5424.FacilityDaemon, rfc5424.SeverityInformational, "late"))
+	c.Check(err, gc.ErrorMatches, "router closed")
+	c.Check(r.Close(), jc.ErrorIsNil)
+	c.Check(central.closed, gc.Equals, 1)
+}
+
+func (s *RouterSuite) TestCloseShared(c *gc.C) {
+	shared := &closingSender{}
+	cfg := s.config()
+	cfg.Destinations["local"] = shared
+	cfg.Destinations["central"] = shared
+	r, err := router.New(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(r.Close(), jc.ErrorIsNil)
+	c.Check(shared.closed, gc.Equals, 1)
+}
+
+type closingSender struct {
+	recordingSender
+	closed int
+	err    error
+}
+
+func (s *closingSender) Close() error {
+	s.closed++
+	return s.err
+}
+
+type recordingSender struct {
+	messages []rfc5424.Message
+	err      error
+}
+
+func (s *recordingSender) Send(msg rfc5424.Message) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.messages = append(s.messages, msg)
+	return nil
+}
+
+func (s *recordingSender) texts() []string {
+	var texts []string
+	for _, msg := range s.messages {
+		texts = append(texts, msg.Msg)
+	}
+	return texts
+}
@@ -6,10 +6,13 @@ package sdelements
 import (
 	"fmt"
 	"net"
+	"path"
 	"reflect"
+	"runtime/debug"
 	"strings"
 	"unicode/utf8"
 
+	"github.com/juju/errors"
 	"github.com/juju/version/v2"
 
 	"github.com/juju/rfc/v2/rfc5424"
@@ -39,6 +42,48 @@ type Origin struct {
 	SoftwareVersion version.Number
 }
 
+// BuildInfoOrigin returns an Origin for the running program, using the
+// build information embedded in the binary. SoftwareName is the last
+// element of the main package path and SoftwareVersion is the main
+// module's version, if it is a release version. The enterprise ID is
+// required because an Origin with a SoftwareName must have one.
+func BuildInfoOrigin(enterpriseID OriginEnterpriseID) (Origin, error) {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return Origin{}, errors.NotFoundf("build info")
+	}
+
+	name := path.Base(info.Path)
+	if info.Path == "" {
+		name = path.Base(info.Main.Path)
+	}
+	if utf8.RuneCountInString(name) > originSoftwareMax {
+		name = string([]rune(name)[:originSoftwareMax])
+	}
+	origin := Origin{
+		EnterpriseID: enterpriseID,
+		SoftwareName: name,
+	}
+	// Pseudo-versions and "(devel)" are not valid version numbers,
+	// so they are left out.
+	if number, err := version.Parse(strings.TrimPrefix(info.Main.Version, "v")); err == nil {
+		origin.SoftwareVersion = number
+	}
+	return origin, errors.Trace(origin.Validate())
+}
+
+// BuildInfoTemplate returns rfc5424.NewMessageTemplate with an Origin
+// element from BuildInfoOrigin added to its structured data.
+func BuildInfoTemplate(enterpriseID OriginEnterpriseID) (rfc5424.MessageTemplate, error) {
+	origin, err := BuildInfoOrigin(enterpriseID)
+	if err != nil {
+		return rfc5424.MessageTemplate{}, errors.Trace(err)
+	}
+	tmpl := rfc5424.NewMessageTemplate()
+	tmpl.StructuredData = rfc5424.StructuredData{origin}
+	return tmpl, nil
+}
+
 // ID returns the SD-ID for this element.
 func (origin Origin) ID() rfc5424.StructuredDataName {
 	return "origin"
@@ -117,3 +117,40 @@ func (s *OriginSuite) TestValidateSoftwareVersionError(c *gc.C) {
 	err := origin.Validate()
 	c.Assert(err, gc.ErrorMatches, "SoftwareVersion too big \\(54 UTF-8 > 32 max\\)")
 }
+
+func (s *OriginSuite) TestBuildInfoOrigin(c *gc.C) {
+	eid := sdelements.OriginEnterpriseID{Number: 32473}
+
+	origin, err := sdelements.BuildInfoOrigin(eid)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(origin.EnterpriseID, jc.DeepEquals, eid)
+	c.Check(origin.SoftwareName, gc.Equals, "sdelements.test")
+	c.Check(origin.SoftwareVersion, gc.Equals, version.Zero)
+}
+
+func (s *OriginSuite) TestBuildInfoOriginNoEnterpriseID(c *gc.C) {
+	_, err := sdelements.BuildInfoOrigin(sdelements.OriginEnterpriseID{})
+
+	c.Check(err, gc.ErrorMatches, "empty EnterpriseID")
+}
+
+func (s *OriginSuite) TestBuildInfoTemplate(c *gc.C) {
+	eid := sdelements.OriginEnterpriseID{Number: 32473}
+
+	tmpl, err := sdelements.BuildInfoTemplate(eid)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(tmpl.AppName, gc.Equals, rfc5424.NewMessageTemplate().AppName)
+	msg := tmpl.Message("a message")
+	c.Check(msg.Validate(), jc.ErrorIsNil)
+	c.Check(msg.StructuredData, gc.HasLen, 1)
+	c.Check(msg.StructuredData[0].ID(), gc.Equals, rfc5424.StructuredDataName("origin"))
+	c.Check(msg.StructuredData[0].Params(), jc.DeepEquals, []rfc5424.StructuredDataParam{
+		{Name: "enterpriseID", Value: "32473"},
+		{Name: "software", Value: "sdelements.test"},
+	})
+
+	_, err = sdelements.BuildInfoTemplate(sdelements.OriginEnterpriseID{})
+	c.Check(err, gc.ErrorMatches, "empty EnterpriseID")
+}
@@ -71,6 +71,29 @@ func (sde Private) Validate() error {
 	return nil
 }
 
+// maxNameLength is the longest allowed SD-NAME.
+const maxNameLength = 32
+
+// NewParam returns a param with the given name, adjusted as necessary
+// to be a valid SD-NAME: characters that are not allowed are replaced
+// with "_" and the name is truncated to 32 characters. An empty name
+// is left empty.
+func NewParam(name, value string) rfc5424.StructuredDataParam {
+	cleaned := strings.Map(func(r rune) rune {
+		if r <= ' ' || r > '~' || strings.ContainsRune(`="]`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+	if len(cleaned) > maxNameLength {
+		cleaned = cleaned[:maxNameLength]
+	}
+	return rfc5424.StructuredDataParam{
+		Name:  rfc5424.StructuredDataName(cleaned),
+		Value: rfc5424.StructuredDataParamValue(value),
+	}
+}
+
 // PrivateEnterpriseNumber is an IANA-registered positive integer that
 // publicly identifies a specific organization.
 //
@@ -0,0 +1,47 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package sdelements_test
+
+import (
+	"strings"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/rfc/v2/rfc5424"
+	"github.com/juju/rfc/v2/rfc5424/sdelements"
+)
+
+type PrivateSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&PrivateSuite{})
+
+func (s *PrivateSuite) TestNewParam(c *gc.C) {
+	for _, test := range []struct {
+		name     string
+		expected rfc5424.StructuredDataName
+	}{{
+		name:     "req.method",
+		expected: "req.method",
+	}, {
+		name:     `a b=c"d]e`,
+		expected: "a_b_c_d_e",
+	}, {
+		name:     "naïve",
+		expected: "na_ve",
+	}, {
+		name:     strings.Repeat("x", 40),
+		expected: rfc5424.StructuredDataName(strings.Repeat("x", 32)),
+	}} {
+		c.Logf("name %q", test.name)
+
+		param := sdelements.NewParam(test.name, "value")
+
+		c.Check(param, jc.DeepEquals, rfc5424.StructuredDataParam{Name: test.expected, Value: "value"})
+		c.Check(param.Validate(), jc.ErrorIsNil)
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package seclog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/rfc/v2/rfc5424"
+)
+
+const cefPrefix = "CEF:"
+
+// CEF is an event in ArcSight's Common Event Format:
+//
+//	CEF:Version|Device Vendor|Device Product|Device Version|Signature ID|Name|Severity|Extension
+//
+// The extension is a space-separated list of key=value pairs.
+type CEF struct {
+	// Version is the version of the format, currently 0 or 1.
+	Version int
+
+	DeviceVendor  string
+	DeviceProduct string
+	DeviceVersion string
+
+	// SignatureID identifies the type of event.
+	SignatureID string
+
+	// Name describes the event.
+	Name string
+
+	// Severity is a number from 0 to 10 or one of "Unknown", "Low",
+	// "Medium", "High" and "Very-High". See SyslogSeverity.
+	Severity string
+
+	// Extension holds the event's other fields, in order.
+	Extension []Field
+}
+
+// String returns the CEF representation of the event. Pipes and
+// backslashes are escaped in the header, and backslashes, equals signs
+// and line breaks in extension values.
+func (e CEF) String() string {
+	var b strings.Builder
+	b.WriteString(cefPrefix)
+	b.WriteString(strconv.Itoa(e.Version))
+	for _, field := range []string{e.DeviceVendor, e.DeviceProduct, e.DeviceVersion, e.SignatureID, e.Name, e.Severity} {
+		b.WriteByte('|')
+		b.WriteString(escapeHeader(field))
+	}
+	b.WriteByte('|')
+	for i, field := range e.Extension {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(field.Key)
+		b.WriteByte('=')
+		b.WriteString(escapeValue(field.Value, "="))
+	}
+	return b.String()
+}
+
+// Validate ensures that the event can be encoded unambiguously.
+func (e CEF) Validate() error {
+	if e.Version < 0 {
+		return fmt.Errorf("negative Version")
+	}
+	if _, err := SyslogSeverity(e.Severity); err != nil {
+		return fmt.Errorf("bad Severity: %v", err)
+	}
+	for i, field := range e.Extension {
+		if field.Key == "" || strings.ContainsAny(field.Key, " =\\\r\n") {
+			return fmt.Errorf("bad Extension key %d: %q", i, field.Key)
+		}
+	}
+	return nil
+}
+
+// Params returns the extension as structured data params.
+func (e CEF) Params() []rfc5424.StructuredDataParam {
+	return fieldParams(e.Extension)
+}
+
+// Message returns a message with the header and the event as its MSG.
+// The header's Severity is replaced with the one for the event's
+// severity. The extension may also be added as structured data using
+// Params.
+func (e CEF) Message(header rfc5424.Header) (rfc5424.Message, error) {
+	if err := e.Validate(); err != nil {
+		return rfc5424.Message{}, errors.NewNotValid(err, "CEF event")
+	}
+	msg := rfc5424.Message{
+		Header: header,
+		Msg:    e.String(),
+	}
+	msg.Severity, _ = SyslogSeverity(e.Severity)
+	return msg, nil
+}
+
+// ParseCEF converts the CEF representation of an event back into a
+// CEF.
+func ParseCEF(str string) (CEF, error) {
+	var e CEF
+	if !strings.HasPrefix(str, cefPrefix) {
+		return e, errors.NotValidf("CEF event without %q prefix", cefPrefix)
+	}
+	header, rest, err := splitHeader(str[len(cefPrefix):], 7)
+	if err != nil {
+		return e, errors.NotValidf("CEF header (%v)", err)
+	}
+	version, err := strconv.Atoi(header[0])
+	if err != nil {
+		return e, errors.NotValidf("CEF version %q", header[0])
+	}
+	e.Version = version
+	e.DeviceVendor = header[1]
+	e.DeviceProduct = header[2]
+	e.DeviceVersion = header[3]
+	e.SignatureID = header[4]
+	e.Name = header[5]
+	e.Severity = header[6]
+
+	e.Extension, err = parseExtension(rest)
+	if err != nil {
+		return e, errors.NotValidf("CEF extension (%v)", err)
+	}
+	return e, nil
+}
+
+// parseExtension splits the extension into fields. Since values may
+// contain spaces, each value runs up to the last space before the next
+// unescaped equals sign.
+func parseExtension(str string) ([]Field, error) {
+	str = strings.TrimRight(str, " ")
+	if str == "" {
+		return nil, nil
+	}
+
+	var equals []int
+	for i := 0; i < len(str); i++ {
+		switch str[i] {
+		case '\\':
+			i++
+		case '=':
+			equals = append(equals, i)
+		}
+	}
+	if len(equals) == 0 {
+		return nil, fmt.Errorf("missing key=value")
+	}
+
+	var fields []Field
+	keyStart := 0
+	for i, eq := range equals {
+		key := str[keyStart:eq]
+		if key == "" || strings.Contains(key, " ") {
+			return nil, fmt.Errorf("bad key %q", key)
+		}
+		valueEnd := len(str)
+		if i+1 < len(equals) {
+			space := strings.LastIndexByte(str[eq+1:equals[i+1]], ' ')
+			if space < 0 {
+				return nil, fmt.Errorf("unescaped = in value of %q", key)
+			}
+			valueEnd = eq + 1 + space
+		}
+		fields = append(fields, Field{
+			Key:   key,
+			Value: unescapeValue(str[eq+1 : valueEnd]),
+		})
+		keyStart = valueEnd + 1
+	}
+	return fields, nil
+}
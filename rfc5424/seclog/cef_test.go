@@ -0,0 +1,123 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package seclog_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/rfc/v2/rfc5424"
+	"github.com/juju/rfc/v2/rfc5424/seclog"
+)
+
+type CEFSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&CEFSuite{})
+
+var cefEvent = seclog.CEF{
+	DeviceVendor:  "Canonical",
+	DeviceProduct: "Juju|Controller",
+	DeviceVersion: "3.6",
+	SignatureID:   "100",
+	Name:          `login failed for C:\users`,
+	Severity:      "7",
+	Extension: []seclog.Field{
+		{Key: "src", Value: "10.0.0.1"},
+		{Key: "msg", Value: "bad password = wrong\nagain"},
+		{Key: "suser", Value: `admin user\`},
+	},
+}
+
+const cefString = `CEF:0|Canonical|Juju\|Controller|3.6|100|login failed for C:\\users|7|` +
+	`src=10.0.0.1 msg=bad password \= wrong\nagain suser=admin user\\`
+
+func (s *CEFSuite) TestString(c *gc.C) {
+	c.Check(cefEvent.String(), gc.Equals, cefString)
+}
+
+func (s *CEFSuite) TestParse(c *gc.C) {
+	event, err := seclog.ParseCEF(cefString)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(event, jc.DeepEquals, cefEvent)
+}
+
+func (s *CEFSuite) TestParseNoExtension(c *gc.C) {
+	event, err := seclog.ParseCEF(`CEF:1|v|p|1|sig|name|Low|`)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(event, jc.DeepEquals, seclog.CEF{
+		Version:       1,
+		DeviceVendor:  "v",
+		DeviceProduct: "p",
+		DeviceVersion: "1",
+		SignatureID:   "sig",
+		Name:          "name",
+		Severity:      "Low",
+	})
+}
+
+func (s *CEFSuite) TestParseInvalid(c *gc.C) {
+	for _, test := range []struct {
+		str string
+		err string
+	}{{
+		str: `LEEF:1.0|v|p|1|sig|`,
+		err: `CEF event without "CEF:" prefix not valid`,
+	}, {
+		str: `CEF:0|v|p|1|sig|name`,
+		err: `CEF header \(expected 7 header fields, got 5\) not valid`,
+	}, {
+		str: `CEF:x|v|p|1|sig|name|1|`,
+		err: `CEF version "x" not valid`,
+	}, {
+		str: `CEF:0|v|p|1|sig|name|1|no pairs`,
+		err: `CEF extension \(missing key=value\) not valid`,
+	}, {
+		str: `CEF:0|v|p|1|sig|name|1|a=b=c`,
+		err: `CEF extension \(unescaped = in value of "a"\) not valid`,
+	}} {
+		c.Logf("trying %q", test.str)
+
+		_, err := seclog.ParseCEF(test.str)
+
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *CEFSuite) TestMessage(c *gc.C) {
+	header := rfc5424.Header{AppName: "juju"}
+
+	msg, err := cefEvent.Message(header)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(msg.Validate(), jc.ErrorIsNil)
+
+	c.Check(msg.Severity, gc.Equals, rfc5424.SeverityError)
+	c.Check(msg.AppName, gc.Equals, rfc5424.AppName("juju"))
+	parsed, err := rfc5424.ParseMessage(msg.String())
+	c.Assert(err, jc.ErrorIsNil)
+	event, err := seclog.ParseCEF(parsed.Msg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(event, jc.DeepEquals, cefEvent)
+	c.Check(cefEvent.Params(), jc.DeepEquals, []rfc5424.StructuredDataParam{
+		{Name: "src", Value: "10.0.0.1"},
+		{Name: "msg", Value: "bad password = wrong\nagain"},
+		{Name: "suser", Value: `admin user\`},
+	})
+}
+
+func (s *CEFSuite) TestMessageInvalid(c *gc.C) {
+	event := cefEvent
+	event.Severity = "extreme"
+
+	_, err := event.Message(rfc5424.Header{})
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `CEF event: bad Severity: severity "extreme" not valid`)
+}
@@ -0,0 +1,7 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+// The seclog package encodes and decodes the security event payloads
+// that SIEMs expect in a syslog MSG: ArcSight's Common Event Format
+// (CEF) and QRadar's Log Event Extended Format (LEEF).
+package seclog
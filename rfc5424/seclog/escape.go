@@ -0,0 +1,103 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package seclog
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/rfc/v2/rfc5424"
+	"github.com/juju/rfc/v2/rfc5424/sdelements"
+)
+
+// Field is a key/value pair from a CEF extension or LEEF attributes.
+type Field struct {
+	Key   string
+	Value string
+}
+
+// fieldParams converts fields into structured data params, adjusting
+// keys as necessary to be valid SD-NAMEs.
+func fieldParams(fields []Field) []rfc5424.StructuredDataParam {
+	params := make([]rfc5424.StructuredDataParam, len(fields))
+	for i, field := range fields {
+		params[i] = sdelements.NewParam(field.Key, field.Value)
+	}
+	return params
+}
+
+// escapeHeader escapes a header field, which is the same for CEF and
+// LEEF.
+var escapeHeader = strings.NewReplacer(`\`, `\\`, `|`, `\|`).Replace
+
+// splitHeader splits off n fields separated by unescaped pipes,
+// unescaping them, and returns the rest of the string.
+func splitHeader(str string, n int) ([]string, string, error) {
+	fields := make([]string, 0, n)
+	var field strings.Builder
+	for i := 0; i < len(str); i++ {
+		switch str[i] {
+		case '\\':
+			if i+1 < len(str) {
+				i++
+			}
+			field.WriteByte(str[i])
+		case '|':
+			fields = append(fields, field.String())
+			field.Reset()
+			if len(fields) == n {
+				return fields, str[i+1:], nil
+			}
+		default:
+			field.WriteByte(str[i])
+		}
+	}
+	return nil, "", fmt.Errorf("expected %d header fields, got %d", n, len(fields))
+}
+
+// unescapeValue removes backslash escapes, where "\n" and "\r" are
+// newline and carriage return and any other escaped character stands
+// for itself.
+func unescapeValue(str string) string {
+	if !strings.Contains(str, `\`) {
+		return str
+	}
+	var value strings.Builder
+	for i := 0; i < len(str); i++ {
+		if str[i] != '\\' || i+1 == len(str) {
+			value.WriteByte(str[i])
+			continue
+		}
+		i++
+		switch str[i] {
+		case 'n':
+			value.WriteByte('\n')
+		case 'r':
+			value.WriteByte('\r')
+		default:
+			value.WriteByte(str[i])
+		}
+	}
+	return value.String()
+}
+
+// escapeValue escapes backslashes, newlines, carriage returns and the
+// given special characters.
+func escapeValue(str string, special string) string {
+	var value strings.Builder
+	for _, r := range str {
+		switch {
+		case r == '\\' || strings.ContainsRune(special, r):
+			value.WriteByte('\\')
+			value.WriteRune(r)
+		case r == '\n':
+			value.WriteString(`\n`)
+		case r == '\r':
+			value.WriteString(`\r`)
+		default:
+			value.WriteRune(r)
+		}
+	}
+	return value.String()
+}
@@ -0,0 +1,225 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package seclog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/rfc/v2/rfc5424"
+)
+
+const leefPrefix = "LEEF:"
+
+// LEEFSeverityKey is the attribute that holds a LEEF event's severity.
+const LEEFSeverityKey = "sev"
+
+// LEEF is an event in QRadar's Log Event Extended Format:
+//
+//	LEEF:1.0|Vendor|Product|Version|EventID|Attributes
+//	LEEF:2.0|Vendor|Product|Version|EventID|Delimiter|Attributes
+//
+// The attributes are key=value pairs separated by the delimiter, which
+// is always a tab in LEEF 1.0.
+type LEEF struct {
+	// Version is the version of the format, "1.0" or "2.0".
+	Version string
+
+	Vendor         string
+	Product        string
+	ProductVersion string
+
+	// EventID identifies the type of event.
+	EventID string
+
+	// Delimiter separates the attributes in LEEF 2.0. If it is not
+	// set then a tab is used.
+	Delimiter rune
+
+	// Attributes holds the event's fields, in order. The severity is
+	// the LEEFSeverityKey attribute.
+	Attributes []Field
+}
+
+func (e LEEF) delimiter() rune {
+	if e.Delimiter == 0 || e.Version == "1.0" {
+		return '\t'
+	}
+	return e.Delimiter
+}
+
+// String returns the LEEF representation of the event. Pipes and
+// backslashes are escaped in the header, and backslashes, the
+// delimiter and line breaks in attribute values. A LEEF 2.0 event
+// always includes the delimiter, in hex if it is not printable.
+func (e LEEF) String() string {
+	var b strings.Builder
+	b.WriteString(leefPrefix)
+	b.WriteString(e.Version)
+	for _, field := range []string{e.Vendor, e.Product, e.ProductVersion, e.EventID} {
+		b.WriteByte('|')
+		b.WriteString(escapeHeader(field))
+	}
+	b.WriteByte('|')
+
+	delim := e.delimiter()
+	if e.Version != "1.0" {
+		if delim > ' ' && delim <= '~' && delim != '|' && delim != '\\' {
+			b.WriteRune(delim)
+		} else {
+			fmt.Fprintf(&b, "x%02X", delim)
+		}
+		b.WriteByte('|')
+	}
+	for i, field := range e.Attributes {
+		if i > 0 {
+			b.WriteRune(delim)
+		}
+		b.WriteString(field.Key)
+		b.WriteByte('=')
+		b.WriteString(escapeValue(field.Value, string(delim)))
+	}
+	return b.String()
+}
+
+// Validate ensures that the event can be encoded unambiguously.
+func (e LEEF) Validate() error {
+	if e.Version != "1.0" && e.Version != "2.0" {
+		return fmt.Errorf("unsupported Version %q", e.Version)
+	}
+	if delim := e.delimiter(); delim > 0xff || delim == '=' {
+		return fmt.Errorf("bad Delimiter %q", delim)
+	}
+	for i, field := range e.Attributes {
+		if field.Key == "" || strings.ContainsAny(field.Key, "=\\\r\n"+string(e.delimiter())) {
+			return fmt.Errorf("bad Attributes key %d: %q", i, field.Key)
+		}
+		if field.Key == LEEFSeverityKey {
+			if _, err := SyslogSeverity(field.Value); err != nil {
+				return fmt.Errorf("bad severity: %v", err)
+			}
+		}
+	}
+	return nil
+}
+
+// Severity returns the event's severity attribute, if it has one.
+func (e LEEF) Severity() (string, bool) {
+	for _, field := range e.Attributes {
+		if field.Key == LEEFSeverityKey {
+			return field.Value, true
+		}
+	}
+	return "", false
+}
+
+// Params returns the attributes as structured data params.
+func (e LEEF) Params() []rfc5424.StructuredDataParam {
+	return fieldParams(e.Attributes)
+}
+
+// Message returns a message with the header and the event as its MSG.
+// If the event has a severity then it replaces the header's Severity.
+// The attributes may also be added as structured data using Params.
+func (e LEEF) Message(header rfc5424.Header) (rfc5424.Message, error) {
+	if err := e.Validate(); err != nil {
+		return rfc5424.Message{}, errors.NewNotValid(err, "LEEF event")
+	}
+	msg := rfc5424.Message{
+		Header: header,
+		Msg:    e.String(),
+	}
+	if sev, ok := e.Severity(); ok {
+		msg.Severity, _ = SyslogSeverity(sev)
+	}
+	return msg, nil
+}
+
+// ParseLEEF converts the LEEF representation of an event back into a
+// LEEF.
+func ParseLEEF(str string) (LEEF, error) {
+	var e LEEF
+	if !strings.HasPrefix(str, leefPrefix) {
+		return e, errors.NotValidf("LEEF event without %q prefix", leefPrefix)
+	}
+	header, rest, err := splitHeader(str[len(leefPrefix):], 5)
+	if err != nil {
+		return e, errors.NotValidf("LEEF header (%v)", err)
+	}
+	e.Version = header[0]
+	e.Vendor = header[1]
+	e.Product = header[2]
+	e.ProductVersion = header[3]
+	e.EventID = header[4]
+
+	switch e.Version {
+	case "1.0":
+	case "2.0":
+		if end := strings.IndexByte(rest, '|'); end >= 0 {
+			if delim, ok := parseDelimiter(rest[:end]); ok {
+				e.Delimiter = delim
+				rest = rest[end+1:]
+			}
+		}
+	default:
+		return e, errors.NotValidf("LEEF version %q", e.Version)
+	}
+
+	e.Attributes = parseAttributes(rest, e.delimiter())
+	return e, nil
+}
+
+// parseDelimiter parses the LEEF 2.0 delimiter field, which is either
+// a single character or its code in hex, e.g. "x09" or "0x09".
+func parseDelimiter(str string) (rune, bool) {
+	if r, size := utf8.DecodeRuneInString(str); size == len(str) && size > 0 {
+		return r, true
+	}
+	hex := strings.TrimPrefix(str, "0")
+	if !strings.HasPrefix(hex, "x") || len(hex) < 2 || len(hex) > 5 {
+		return 0, false
+	}
+	hex = hex[1:]
+	code, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return 0, false
+	}
+	return rune(code), true
+}
+
+func parseAttributes(str string, delim rune) []Field {
+	var fields []Field
+	var attr strings.Builder
+	addAttr := func() {
+		if attr.Len() == 0 {
+			return
+		}
+		raw := attr.String()
+		attr.Reset()
+		key, value, _ := strings.Cut(raw, "=")
+		fields = append(fields, Field{
+			Key:   key,
+			Value: unescapeValue(value),
+		})
+	}
+	escaped := false
+	for _, r := range str {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == delim:
+			addAttr()
+			continue
+		}
+		attr.WriteRune(r)
+	}
+	addAttr()
+	return fields
+}
@@ -0,0 +1,154 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package seclog_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/rfc/v2/rfc5424"
+	"github.com/juju/rfc/v2/rfc5424/seclog"
+)
+
+type LEEFSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&LEEFSuite{})
+
+func (s *LEEFSuite) TestVersion1(c *gc.C) {
+	event := seclog.LEEF{
+		Version:        "1.0",
+		Vendor:         "Canonical",
+		Product:        "Juju",
+		ProductVersion: "3.6",
+		EventID:        "login|failed",
+		Attributes: []seclog.Field{
+			{Key: "src", Value: "10.0.0.1"},
+			{Key: "sev", Value: "9"},
+			{Key: "msg", Value: "tab\there"},
+		},
+	}
+	const expected = "LEEF:1.0|Canonical|Juju|3.6|login\\|failed|src=10.0.0.1\tsev=9\tmsg=tab\\\there"
+
+	c.Check(event.String(), gc.Equals, expected)
+	parsed, err := seclog.ParseLEEF(expected)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(parsed, jc.DeepEquals, event)
+}
+
+func (s *LEEFSuite) TestVersion2(c *gc.C) {
+	event := seclog.LEEF{
+		Version:        "2.0",
+		Vendor:         "Canonical",
+		Product:        "Juju",
+		ProductVersion: "3.6",
+		EventID:        "login",
+		Delimiter:      '^',
+		Attributes: []seclog.Field{
+			{Key: "src", Value: "10.0.0.1"},
+			{Key: "msg", Value: "a^b=c"},
+		},
+	}
+	const expected = `LEEF:2.0|Canonical|Juju|3.6|login|^|src=10.0.0.1^msg=a\^b=c`
+
+	c.Check(event.String(), gc.Equals, expected)
+	parsed, err := seclog.ParseLEEF(expected)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(parsed, jc.DeepEquals, event)
+}
+
+func (s *LEEFSuite) TestVersion2Delimiters(c *gc.C) {
+	for str, expected := range map[string]seclog.LEEF{
+		"LEEF:2.0|v|p|1|id|x09|a=b\tc=d": {
+			Delimiter:  '\t',
+			Attributes: []seclog.Field{{Key: "a", Value: "b"}, {Key: "c", Value: "d"}},
+		},
+		"LEEF:2.0|v|p|1|id|0x7C|a=b": {
+			Delimiter:  '|',
+			Attributes: []seclog.Field{{Key: "a", Value: "b"}},
+		},
+		"LEEF:2.0|v|p|1|id|a=b\tc=d": {
+			Attributes: []seclog.Field{{Key: "a", Value: "b"}, {Key: "c", Value: "d"}},
+		},
+	} {
+		c.Logf("trying %q", str)
+		expected.Version = "2.0"
+		expected.Vendor = "v"
+		expected.Product = "p"
+		expected.ProductVersion = "1"
+		expected.EventID = "id"
+
+		event, err := seclog.ParseLEEF(str)
+
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(event, jc.DeepEquals, expected)
+	}
+	c.Check(seclog.LEEF{Version: "2.0"}.String(), gc.Equals, "LEEF:2.0|||||x09|")
+}
+
+func (s *LEEFSuite) TestParseInvalid(c *gc.C) {
+	for _, test := range []struct {
+		str string
+		err string
+	}{{
+		str: `CEF:0|v|p|1|sig|name|1|`,
+		err: `LEEF event without "LEEF:" prefix not valid`,
+	}, {
+		str: `LEEF:1.0|v|p`,
+		err: `LEEF header \(expected 5 header fields, got 2\) not valid`,
+	}, {
+		str: `LEEF:3.0|v|p|1|id|`,
+		err: `LEEF version "3.0" not valid`,
+	}} {
+		c.Logf("trying %q", test.str)
+
+		_, err := seclog.ParseLEEF(test.str)
+
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *LEEFSuite) TestMessage(c *gc.C) {
+	event := seclog.LEEF{
+		Version: "1.0",
+		Vendor:  "Canonical",
+		Product: "Juju",
+		EventID: "login",
+		Attributes: []seclog.Field{
+			{Key: "sev", Value: "4"},
+			{Key: "usrName", Value: "admin"},
+		},
+	}
+	header := rfc5424.Header{
+		Priority: rfc5424.Priority{
+			Severity: rfc5424.SeverityDebug,
+		},
+	}
+
+	msg, err := event.Message(header)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(msg.Severity, gc.Equals, rfc5424.SeverityWarning)
+	c.Check(msg.Msg, gc.Equals, event.String())
+	c.Check(event.Params(), jc.DeepEquals, []rfc5424.StructuredDataParam{
+		{Name: "sev", Value: "4"},
+		{Name: "usrName", Value: "admin"},
+	})
+}
+
+func (s *LEEFSuite) TestMessageInvalid(c *gc.C) {
+	event := seclog.LEEF{
+		Version:    "1.0",
+		Attributes: []seclog.Field{{Key: "bad key=", Value: "x"}},
+	}
+
+	_, err := event.Message(rfc5424.Header{})
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `LEEF event: bad Attributes key 0: "bad key="`)
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package seclog
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/rfc/v2/rfc5424"
+)
+
+// SyslogSeverity returns the syslog severity for a CEF or LEEF
+// severity, which is a number from 0 to 10 or one of CEF's names:
+//
+//	0                DEBUG
+//	1-2, Low         INFO
+//	3                NOTICE
+//	4-6, Medium      WARNING
+//	7-8, High        ERROR
+//	9, Very-High     CRIT
+//	10               ALERT
+//	Unknown          INFO
+func SyslogSeverity(str string) (rfc5424.Severity, error) {
+	switch strings.ToLower(str) {
+	case "unknown", "low":
+		return rfc5424.SeverityInformational, nil
+	case "medium":
+		return rfc5424.SeverityWarning, nil
+	case "high":
+		return rfc5424.SeverityError, nil
+	case "very-high":
+		return rfc5424.SeverityCrit, nil
+	}
+
+	level, err := strconv.Atoi(str)
+	if err != nil || level < 0 || level > 10 {
+		return 0, errors.NotValidf("severity %q", str)
+	}
+	switch {
+	case level == 0:
+		return rfc5424.SeverityDebug, nil
+	case level < 3:
+		return rfc5424.SeverityInformational, nil
+	case level == 3:
+		return rfc5424.SeverityNotice, nil
+	case level < 7:
+		return rfc5424.SeverityWarning, nil
+	case level < 9:
+		return rfc5424.SeverityError, nil
+	case level == 9:
+		return rfc5424.SeverityCrit, nil
+	default:
+		return rfc5424.SeverityAlert, nil
+	}
+}
+
+// CEFSeverity returns the CEF severity for the syslog severity. It is
+// the reverse of SyslogSeverity, except that EMERGENCY is also 10.
+func CEFSeverity(sev rfc5424.Severity) int {
+	switch sev {
+	case rfc5424.SeverityEmergency, rfc5424.SeverityAlert:
+		return 10
+	case rfc5424.SeverityCrit:
+		return 9
+	case rfc5424.SeverityError:
+		return 7
+	case rfc5424.SeverityWarning:
+		return 5
+	case rfc5424.SeverityNotice:
+		return 3
+	case rfc5424.SeverityDebug:
+		return 0
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package seclog_test
+
+import (
+	"strconv"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/rfc/v2/rfc5424"
+	"github.com/juju/rfc/v2/rfc5424/seclog"
+)
+
+type SeveritySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&SeveritySuite{})
+
+func (s *SeveritySuite) TestSyslogSeverity(c *gc.C) {
+	for str, expected := range map[string]rfc5424.Severity{
+		"0":         rfc5424.SeverityDebug,
+		"2":         rfc5424.SeverityInformational,
+		"3":         rfc5424.SeverityNotice,
+		"6":         rfc5424.SeverityWarning,
+		"7":         rfc5424.SeverityError,
+		"9":         rfc5424.SeverityCrit,
+		"10":        rfc5424.SeverityAlert,
+		"Unknown":   rfc5424.SeverityInformational,
+		"Low":       rfc5424.SeverityInformational,
+		"medium":    rfc5424.SeverityWarning,
+		"High":      rfc5424.SeverityError,
+		"Very-High": rfc5424.SeverityCrit,
+	} {
+		c.Logf("trying %q", str)
+
+		sev, err := seclog.SyslogSeverity(str)
+
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(sev, gc.Equals, expected)
+	}
+}
+
+func (s *SeveritySuite) TestSyslogSeverityInvalid(c *gc.C) {
+	for _, str := range []string{"", "11", "-1", "extreme"} {
+		_, err := seclog.SyslogSeverity(str)
+
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *SeveritySuite) TestCEFSeverityRoundTrip(c *gc.C) {
+	for sev := rfc5424.SeverityAlert; sev <= rfc5424.SeverityDebug; sev++ {
+		c.Logf("trying %s", sev)
+		back, err := seclog.SyslogSeverity(strconv.Itoa(seclog.CEFSeverity(sev)))
+
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(back, gc.Equals, sev)
+	}
+	c.Check(seclog.CEFSeverity(rfc5424.SeverityEmergency), gc.Equals, 10)
+}
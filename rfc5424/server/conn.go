@@ -0,0 +1,136 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package server
+
+import (
+	"crypto/tls"
+	"io"
+	"net"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/rfc/v2/rfc5424"
+	"github.com/juju/rfc/v2/rfc5424/relp"
+)
+
+func (s *Server) serveConn(conn net.Conn) {
+	info, ok := s.connInfo(conn)
+	if !ok {
+		return
+	}
+
+	reader := rfc5424.NewFrameReader(conn, s.cfg.MaxMessageSize)
+	for {
+		s.setReadDeadline(conn)
+		frame, framing, err := reader.ReadFrame()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			if !s.shuttingDown() {
+				s.handleError(info, errors.Trace(err))
+			}
+			return
+		}
+		s.handle(info, frame, framing)
+	}
+}
+
+func (s *Server) serveRELPConn(conn net.Conn) {
+	info, ok := s.connInfo(conn)
+	if !ok {
+		return
+	}
+
+	err := relp.ServeConn(deadlineConn{conn, s}, s.cfg.MaxMessageSize, func(frame []byte) error {
+		return s.handle(info, frame, rfc5424.FramingOctetCounting)
+	})
+	if err != nil && err != io.EOF && !s.shuttingDown() {
+		s.handleError(info, errors.Trace(err))
+	}
+}
+
+// connInfo returns the metadata of the connection, completing the TLS
+// handshake first if it uses TLS. It returns false if the handshake
+// failed.
+func (s *Server) connInfo(conn net.Conn) (ConnInfo, bool) {
+	info := ConnInfo{
+		Network:    conn.LocalAddr().Network(),
+		LocalAddr:  conn.LocalAddr(),
+		RemoteAddr: conn.RemoteAddr(),
+	}
+
+	if tlsConn, ok := conn.(*tls.Conn); ok {
+		s.setReadDeadline(conn)
+		if err := tlsConn.Handshake(); err != nil {
+			if !s.shuttingDown() {
+				s.handleError(info, errors.Annotate(err, "TLS handshake"))
+			}
+			return info, false
+		}
+		state := tlsConn.ConnectionState()
+		info.TLS = &state
+	}
+	return info, true
+}
+
+// deadlineConn sets the read deadline before each read, for RELP
+// sessions, whose frames are read by the relp package.
+type deadlineConn struct {
+	net.Conn
+	s *Server
+}
+
+func (conn deadlineConn) Read(buf []byte) (int, error) {
+	conn.s.setReadDeadline(conn.Conn)
+	return conn.Conn.Read(buf)
+}
+
+// setReadDeadline sets the deadline for the next read from the
+// connection. This is done while holding the lock so that it cannot
+// undo the interruption by Shutdown. Once shutting down, only frames
+// that have already been read into the buffer are handled.
+func (s *Server) setReadDeadline(conn net.Conn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var deadline time.Time
+	switch {
+	case s.inShutdown:
+		deadline = time.Now()
+	case s.cfg.IdleTimeout > 0:
+		deadline = time.Now().Add(s.cfg.IdleTimeout)
+	}
+	conn.SetReadDeadline(deadline)
+}
+
+// handle passes the message to the handler. If it cannot be parsed
+// then the *ParseError is returned, after passing it to the error
+// handler.
+func (s *Server) handle(info ConnInfo, frame []byte, framing rfc5424.Framing) error {
+	raw, err := rfc5424.ParseRawMessage(string(frame))
+	if err != nil {
+		text := make([]byte, len(frame))
+		copy(text, frame)
+		parseErr := &ParseError{Raw: text, Err: err}
+		s.handleError(info, parseErr)
+		return parseErr
+	}
+	if s.cfg.Filter != nil && !s.cfg.Filter.Match(raw.Message) {
+		return nil
+	}
+	s.cfg.Handler.HandleSyslog(Message{
+		Message: raw.Message,
+		Raw:     raw,
+		Conn:    info,
+		Framing: framing,
+	})
+	return nil
+}
+
+func (s *Server) handleError(info ConnInfo, err error) {
+	if s.cfg.ErrorHandler != nil {
+		s.cfg.ErrorHandler(info, err)
+	}
+}
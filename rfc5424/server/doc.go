@@ -0,0 +1,7 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+// The server package holds an RFC 5424 syslog receiver that accepts
+// messages over TCP, TLS, UDP, Unix sockets and RELP and passes them,
+// parsed, to a Handler.
+package server
@@ -0,0 +1,129 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package server
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+
+	"github.com/juju/rfc/v2/rfc5424"
+)
+
+// Handler defines an interface for handling received syslog messages.
+// A Handler may be called concurrently for messages received on
+// different connections, but calls for any one connection are made
+// in the order the messages arrived.
+type Handler interface {
+	HandleSyslog(msg Message)
+}
+
+// HandlerFunc is a Handler that is implemented as a function.
+type HandlerFunc func(Message)
+
+// HandleSyslog calls f(msg).
+func (f HandlerFunc) HandleSyslog(msg Message) {
+	f(msg)
+}
+
+// Message is a received syslog message along with information about
+// the connection it arrived on.
+type Message struct {
+	rfc5424.Message
+
+	// Raw holds the message along with the text it was received as,
+	// so that a relay can forward it unchanged (see
+	// rfc5424.RawMessage.Verbatim).
+	Raw *rfc5424.RawMessage
+
+	// Conn describes the connection the message was received on.
+	Conn ConnInfo
+
+	// Framing is the framing the message was received with. It is
+	// only meaningful for stream transports, and is always
+	// FramingOctetCounting for RELP.
+	Framing rfc5424.Framing
+}
+
+// MarshalJSON implements json.Marshaler. The message is encoded as an
+// rfc5424.Message is, with the connection and framing added:
+//
+//	{
+//	  "facility": "daemon",
+//	  ...
+//	  "msg": "An application event log entry...",
+//	  "network": "tcp",
+//	  "local-addr": "10.0.0.1:601",
+//	  "remote-addr": "10.0.0.2:49152",
+//	  "tls": true,
+//	  "framing": "octet-counting"
+//	}
+//
+// Addresses that are not known are left out.
+func (m Message) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(m.Message)
+	if err != nil {
+		return nil, err
+	}
+	extra := jsonConn{
+		Network: m.Conn.Network,
+		TLS:     m.Conn.TLS != nil,
+		Framing: m.Framing.String(),
+	}
+	if m.Conn.LocalAddr != nil {
+		extra.LocalAddr = m.Conn.LocalAddr.String()
+	}
+	if m.Conn.RemoteAddr != nil {
+		extra.RemoteAddr = m.Conn.RemoteAddr.String()
+	}
+	extraData, err := json.Marshal(extra)
+	if err != nil {
+		return nil, err
+	}
+	// Both are objects, so join their fields.
+	data = append(data[:len(data)-1], ',')
+	return append(data, extraData[1:]...), nil
+}
+
+type jsonConn struct {
+	Network    string `json:"network,omitempty"`
+	LocalAddr  string `json:"local-addr,omitempty"`
+	RemoteAddr string `json:"remote-addr,omitempty"`
+	TLS        bool   `json:"tls,omitempty"`
+	Framing    string `json:"framing"`
+}
+
+// ConnInfo holds the metadata of a connection, or for datagram
+// transports of the packet, that a message was received on.
+type ConnInfo struct {
+	// Network is the name of the network, e.g. "tcp" or "udp".
+	Network string
+
+	// LocalAddr is the server's address.
+	LocalAddr net.Addr
+
+	// RemoteAddr is the sender's address. It may be nil for
+	// unnamed Unix sockets.
+	RemoteAddr net.Addr
+
+	// TLS holds the state of the TLS session, including the peer's
+	// certificates. It is nil for connections without TLS.
+	TLS *tls.ConnectionState
+}
+
+// ParseError is passed to the server's error handler when a received
+// message cannot be parsed.
+type ParseError struct {
+	// Raw is the message as it was received, without framing.
+	Raw []byte
+
+	// Err is the reason the message could not be parsed.
+	Err error
+}
+
+// Error implements error.
+func (err *ParseError) Error() string {
+	return fmt.Sprintf("parsing syslog message: %v", err.Err)
+}
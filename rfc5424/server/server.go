@@ -0,0 +1,363 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/rfc/v2/rfc5424"
+)
+
+// DefaultMaxMessageSize is the maximum message size used when the
+// config does not set one. RFC 5425 requires receivers to accept at
+// least 2048 octets and recommends 8192; this is larger still, so
+// that long messages such as stack traces are not lost.
+const DefaultMaxMessageSize = 64 * 1024
+
+// A temporary error accepting a connection, such as running out of
+// file descriptors, is retried after a delay that doubles from
+// minAcceptDelay up to maxAcceptDelay.
+const (
+	minAcceptDelay = 5 * time.Millisecond
+	maxAcceptDelay = time.Second
+)
+
+// ErrServerClosed is returned by the Serve methods after the server
+// has been shut down or closed.
+var ErrServerClosed = errors.New("syslog server closed")
+
+// Config is the configuration for a syslog server.
+type Config struct {
+	// Handler is called for every message that is received and
+	// successfully parsed.
+	Handler Handler
+
+	// ErrorHandler, if set, is called for any message that could not
+	// be parsed (with a *ParseError), for any error that ends a
+	// connection other than the peer closing it, and for temporary
+	// errors accepting connections.
+	ErrorHandler func(ConnInfo, error)
+
+	// Filter, if set, selects the messages that are passed to the
+	// Handler. Other messages are dropped.
+	Filter rfc5424.Matcher
+
+	// MaxMessageSize is the largest message that will be accepted.
+	// A stream connection sending a larger message is closed and a
+	// larger datagram is dropped. If not set, DefaultMaxMessageSize
+	// is used.
+	MaxMessageSize int
+
+	// IdleTimeout is how long a stream connection may go without
+	// sending a message before it is closed. If not set then idle
+	// connections are never closed.
+	IdleTimeout time.Duration
+
+	// MaxConnections is the maximum number of concurrent stream
+	// connections. Connections beyond the limit are closed as soon
+	// as they are accepted. If not set then there is no limit.
+	MaxConnections int
+}
+
+// Validate ensures that the config is correct.
+func (cfg Config) Validate() error {
+	if cfg.Handler == nil {
+		return errors.NotValidf("nil Handler")
+	}
+	if cfg.MaxMessageSize < 0 {
+		return errors.NotValidf("negative MaxMessageSize")
+	}
+	if cfg.IdleTimeout < 0 {
+		return errors.NotValidf("negative IdleTimeout")
+	}
+	if cfg.MaxConnections < 0 {
+		return errors.NotValidf("negative MaxConnections")
+	}
+	return nil
+}
+
+// Server receives syslog messages on any number of listeners and
+// passes them to its handler.
+type Server struct {
+	cfg Config
+
+	mu         sync.Mutex
+	wg         sync.WaitGroup
+	inShutdown bool
+	listeners  map[io.Closer]struct{}
+	conns      map[net.Conn]struct{}
+}
+
+// New returns a new server for the given config. The server does not
+// receive anything until one of the Serve or ListenAndServe methods
+// is called.
+func New(cfg Config) (*Server, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if cfg.MaxMessageSize == 0 {
+		cfg.MaxMessageSize = DefaultMaxMessageSize
+	}
+	s := &Server{
+		cfg:       cfg,
+		listeners: make(map[io.Closer]struct{}),
+		conns:     make(map[net.Conn]struct{}),
+	}
+	return s, nil
+}
+
+// ListenAndServe listens on the given network address and serves
+// syslog messages received there. The network may be any of "tcp",
+// "tcp4", "tcp6" and "unix" for stream transports, or "udp", "udp4",
+// "udp6" and "unixgram" for datagram transports. It always returns
+// a non-nil error.
+func (s *Server) ListenAndServe(network, address string) error {
+	switch network {
+	case "tcp", "tcp4", "tcp6", "unix":
+		l, err := net.Listen(network, address)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return s.Serve(l)
+	case "udp", "udp4", "udp6", "unixgram":
+		pc, err := net.ListenPacket(network, address)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return s.ServePacket(pc)
+	default:
+		return errors.NotSupportedf("network %q", network)
+	}
+}
+
+// ListenAndServeTLS listens on the given TCP address and serves
+// syslog messages received there over TLS. It always returns a
+// non-nil error.
+func (s *Server) ListenAndServeTLS(address string, cfg *tls.Config) error {
+	l, err := net.Listen("tcp", address)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return s.ServeTLS(l, cfg)
+}
+
+// ServeTLS is like Serve except that connections are wrapped with
+// TLS using the given config.
+func (s *Server) ServeTLS(l net.Listener, cfg *tls.Config) error {
+	if cfg == nil || (len(cfg.Certificates) == 0 && cfg.GetCertificate == nil && cfg.GetConfigForClient == nil) {
+		l.Close()
+		return errors.NotValidf("TLS config without certificates")
+	}
+	return s.Serve(tls.NewListener(l, cfg))
+}
+
+// Serve accepts stream connections on the listener and serves syslog
+// messages received on them, until the listener fails or the server
+// is shut down. Temporary errors accepting a connection are retried
+// with increasing delays. The framing of each message is detected from its
+// first octet. Serve always returns a non-nil error, which is
+// ErrServerClosed after Shutdown or Close.
+func (s *Server) Serve(l net.Listener) error {
+	return s.serve(l, s.serveConn)
+}
+
+// ServeRELP is like Serve except that messages are received over RELP,
+// acknowledging each once the handler has returned. A message that
+// cannot be parsed is rejected, as well as being passed to the error
+// handler. To serve RELP over TLS, wrap the listener with
+// tls.NewListener.
+func (s *Server) ServeRELP(l net.Listener) error {
+	return s.serve(l, s.serveRELPConn)
+}
+
+func (s *Server) serve(l net.Listener, serveConn func(net.Conn)) error {
+	if !s.trackListener(l, false) {
+		l.Close()
+		return ErrServerClosed
+	}
+	defer s.untrackListener(l)
+
+	var delay time.Duration
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if s.shuttingDown() {
+				return ErrServerClosed
+			}
+			if netErr, ok := err.(net.Error); ok && netErr.Temporary() {
+				delay = min(max(2*delay, minAcceptDelay), maxAcceptDelay)
+				s.handleError(ConnInfo{Network: l.Addr().Network(), LocalAddr: l.Addr()}, err)
+				time.Sleep(delay)
+				continue
+			}
+			return errors.Trace(err)
+		}
+		delay = 0
+		if !s.trackConn(conn) {
+			conn.Close()
+			continue
+		}
+		go func() {
+			defer s.wg.Done()
+			defer s.untrackConn(conn)
+			serveConn(conn)
+		}()
+	}
+}
+
+// ServePacket reads datagrams from the packet connection and serves
+// the syslog message in each, until the connection fails or the
+// server is shut down. It always returns a non-nil error, which is
+// ErrServerClosed after Shutdown or Close.
+func (s *Server) ServePacket(pc net.PacketConn) error {
+	// Unlike a stream listener, the packet connection is itself
+	// what delivers messages, so Shutdown waits for it.
+	if !s.trackListener(pc, true) {
+		pc.Close()
+		return ErrServerClosed
+	}
+	defer s.wg.Done()
+	defer s.untrackListener(pc)
+
+	// One extra byte allows oversized datagrams to be detected.
+	buf := make([]byte, s.cfg.MaxMessageSize+1)
+	for {
+		n, addr, err := pc.ReadFrom(buf)
+		if err != nil {
+			if s.shuttingDown() {
+				return ErrServerClosed
+			}
+			return errors.Trace(err)
+		}
+		info := ConnInfo{
+			Network:    pc.LocalAddr().Network(),
+			LocalAddr:  pc.LocalAddr(),
+			RemoteAddr: addr,
+		}
+		if n > s.cfg.MaxMessageSize {
+			s.handleError(info, rfc5424.ErrFrameTooLarge)
+			continue
+		}
+		// Many senders terminate datagrams as they would a stream
+		// frame, so a trailing LF (or NUL) is not part of the message.
+		frame := bytes.TrimRight(buf[:n], "\r\n\x00")
+		s.handle(info, frame, rfc5424.FramingNonTransparent)
+	}
+}
+
+// Shutdown gracefully stops the server. It closes all listeners and
+// then waits for every open connection to finish handling the
+// messages it has already received before closing it. If the context
+// expires first then the remaining connections are closed and the
+// context's error is returned, without waiting for any handlers that
+// are still running.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	s.inShutdown = true
+	s.closeListenersLocked()
+	for conn := range s.conns {
+		// Interrupt any pending read; a connection that is part
+		// way through handling a message finishes that first.
+		// See setReadDeadline.
+		conn.SetReadDeadline(time.Now())
+	}
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.closeAll()
+		return ctx.Err()
+	}
+}
+
+// Close immediately stops the server, closing all listeners and open
+// connections, and waits for any running handlers to return.
+func (s *Server) Close() error {
+	s.closeAll()
+	s.wg.Wait()
+	return nil
+}
+
+// closeAll closes all listeners and open connections.
+func (s *Server) closeAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.inShutdown = true
+	s.closeListenersLocked()
+	for conn := range s.conns {
+		conn.Close()
+	}
+}
+
+func (s *Server) closeListenersLocked() {
+	for l := range s.listeners {
+		l.Close()
+		delete(s.listeners, l)
+	}
+}
+
+func (s *Server) shuttingDown() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.inShutdown
+}
+
+func (s *Server) trackListener(l io.Closer, wait bool) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.inShutdown {
+		return false
+	}
+	s.listeners[l] = struct{}{}
+	if wait {
+		s.wg.Add(1)
+	}
+	return true
+}
+
+func (s *Server) untrackListener(l io.Closer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.listeners[l]; ok {
+		l.Close()
+		delete(s.listeners, l)
+	}
+}
+
+func (s *Server) trackConn(conn net.Conn) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.inShutdown {
+		return false
+	}
+	if s.cfg.MaxConnections > 0 && len(s.conns) >= s.cfg.MaxConnections {
+		return false
+	}
+	s.conns[conn] = struct{}{}
+	s.wg.Add(1)
+	return true
+}
+
+func (s *Server) untrackConn(conn net.Conn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	conn.Close()
+	delete(s.conns, conn)
+}
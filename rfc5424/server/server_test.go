@@ -0,0 +1,452 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package server_test
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"io"
+	"net"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/rfc/v2/rfc5424"
+	"github.com/juju/rfc/v2/rfc5424/relp"
+	"github.com/juju/rfc/v2/rfc5424/rfc5424test"
+	"github.com/juju/rfc/v2/rfc5424/server"
+)
+
+const testMessage = `<28>1 1970-01-01T15:05:21.000000123Z a.b.org an-app 119 xyz... [spam x="y"] a message`
+
+type ServerSuite struct {
+	testing.IsolationSuite
+
+	received chan server.Message
+	errs     chan error
+}
+
+var _ = gc.Suite(&ServerSuite{})
+
+func (s *ServerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.received = make(chan server.Message, 10)
+	s.errs = make(chan error, 10)
+}
+
+func (s *ServerSuite) config() server.Config {
+	return server.Config{
+		Handler: server.HandlerFunc(func(msg server.Message) {
+			s.received <- msg
+		}),
+		ErrorHandler: func(_ server.ConnInfo, err error) {
+			s.errs <- err
+		},
+	}
+}
+
+func (s *ServerSuite) newServer(c *gc.C, cfg server.Config) *server.Server {
+	srv, err := server.New(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { srv.Close() })
+	return srv
+}
+
+func (s *ServerSuite) serve(c *gc.C, srv *server.Server, network string) net.Addr {
+	switch network {
+	case "udp", "unixgram":
+		address := "127.0.0.1:0"
+		if network == "unixgram" {
+			address = filepath.Join(c.MkDir(), "syslog.sock")
+		}
+		pc, err := net.ListenPacket(network, address)
+		c.Assert(err, jc.ErrorIsNil)
+		go srv.ServePacket(pc)
+		return pc.LocalAddr()
+	default:
+		address := "127.0.0.1:0"
+		if network == "unix" {
+			address = filepath.Join(c.MkDir(), "syslog.sock")
+		}
+		l, err := net.Listen(network, address)
+		c.Assert(err, jc.ErrorIsNil)
+		go srv.Serve(l)
+		return l.Addr()
+	}
+}
+
+func (s *ServerSuite) next(c *gc.C) server.Message {
+	select {
+	case msg := <-s.received:
+		return msg
+	case err := <-s.errs:
+		c.Fatalf("unexpected error: %v", err)
+	case <-time.After(10 * time.Second):
+		c.Fatal("timed out waiting for message")
+	}
+	panic("unreachable")
+}
+
+func (s *ServerSuite) nextErr(c *gc.C) error {
+	select {
+	case msg := <-s.received:
+		c.Fatalf("unexpected message: %v", msg)
+	case err := <-s.errs:
+		return err
+	case <-time.After(10 * time.Second):
+		c.Fatal("timed out waiting for error")
+	}
+	panic("unreachable")
+}
+
+func (s *ServerSuite) TestNewValidates(c *gc.C) {
+	_, err := server.New(server.Config{})
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, "nil Handler not valid")
+}
+
+func (s *ServerSuite) TestStreamFraming(c *gc.C) {
+	srv := s.newServer(c, s.config())
+	addr := s.serve(c, srv, "tcp")
+
+	conn, err := net.Dial("tcp", addr.String())
+	c.Assert(err, jc.ErrorIsNil)
+	defer conn.Close()
+	_, err = conn.Write(rfc5424.FramingNonTransparent.Frame([]byte(testMessage)))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = conn.Write(rfc5424.FramingOctetCounting.Frame([]byte(testMessage)))
+	c.Assert(err, jc.ErrorIsNil)
+
+	msg := s.next(c)
+	c.Check(msg.String(), gc.Equals, testMessage)
+	c.Check(msg.Framing, gc.Equals, rfc5424.FramingNonTransparent)
+	c.Check(msg.Conn.Network, gc.Equals, "tcp")
+	c.Check(msg.Conn.RemoteAddr.String(), gc.Equals, conn.LocalAddr().String())
+	c.Check(msg.Conn.TLS, gc.IsNil)
+
+	msg = s.next(c)
+	c.Check(msg.String(), gc.Equals, testMessage)
+	c.Check(msg.Framing, gc.Equals, rfc5424.FramingOctetCounting)
+}
+
+func (s *ServerSuite) TestDatagram(c *gc.C) {
+	for _, network := range []string{"udp", "unixgram", "unix"} {
+		c.Logf("network %q", network)
+		srv := s.newServer(c, s.config())
+		addr := s.serve(c, srv, network)
+
+		conn, err := net.Dial(network, addr.String())
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = conn.Write([]byte(testMessage + "\n"))
+		c.Assert(err, jc.ErrorIsNil)
+		conn.Close()
+
+		msg := s.next(c)
+		c.Check(msg.Msg, gc.Equals, "a message")
+		c.Check(msg.Conn.Network, gc.Equals, network)
+	}
+}
+
+func (s *ServerSuite) TestFilter(c *gc.C) {
+	cfg := s.config()
+	cfg.Filter = rfc5424.MatcherFunc(func(msg rfc5424.Message) bool {
+		return msg.Severity <= rfc5424.SeverityError
+	})
+	srv := s.newServer(c, cfg)
+	addr := s.serve(c, srv, "tcp")
+
+	conn, err := net.Dial("tcp", addr.String())
+	c.Assert(err, jc.ErrorIsNil)
+	defer conn.Close()
+	_, err = conn.Write(rfc5424.FramingOctetCounting.Frame([]byte(testMessage)))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = conn.Write(rfc5424.FramingOctetCounting.Frame([]byte(`<27>1 - - - - - - an error`)))
+	c.Assert(err, jc.ErrorIsNil)
+
+	msg := s.next(c)
+	c.Check(msg.Msg, gc.Equals, "an error")
+}
+
+func (s *ServerSuite) TestServeRELP(c *gc.C) {
+	srv := s.newServer(c, s.config())
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	go srv.ServeRELP(l)
+
+	conn, err := net.Dial("tcp", l.Addr().String())
+	c.Assert(err, jc.ErrorIsNil)
+	defer conn.Close()
+	reader := relp.NewFrameReader(conn, 0)
+	command := func(txnr int, command, data string) string {
+		frame := relp.Frame{Txnr: txnr, Command: command, Data: []byte(data)}
+		_, err := conn.Write(frame.Bytes())
+		c.Assert(err, jc.ErrorIsNil)
+		rsp, err := reader.ReadFrame()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(rsp.Txnr, gc.Equals, txnr)
+		c.Assert(rsp.Command, gc.Equals, relp.CommandResponse)
+		return string(rsp.Data)
+	}
+
+	rsp := command(1, relp.CommandOpen, "relp_version=0\ncommands=syslog")
+	c.Check(rsp, gc.Matches, "200 OK\n(.|\n)*commands=syslog")
+	c.Check(command(2, relp.CommandSyslog, testMessage), gc.Equals, "200 OK")
+	msg := s.next(c)
+	c.Check(msg.String(), gc.Equals, testMessage)
+	c.Check(msg.Framing, gc.Equals, rfc5424.FramingOctetCounting)
+
+	c.Check(command(3, relp.CommandSyslog, "not syslog"), gc.Matches, "500 parsing syslog message: .*")
+	c.Check(s.nextErr(c), gc.FitsTypeOf, &server.ParseError{})
+
+	c.Check(command(4, relp.CommandClose, ""), gc.Equals, "200 OK")
+	_, err = reader.ReadFrame()
+	c.Check(err, gc.Equals, io.EOF)
+}
+
+func (s *ServerSuite) TestTLSPeerCertificates(c *gc.C) {
+	serverTLS, clientTLS := rfc5424test.NewTLSConfigs(rfc5424test.TLSOptions{Mutual: true})
+	clientCert := clientTLS.Certificates[0]
+
+	srv := s.newServer(c, s.config())
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	go srv.ServeTLS(l, serverTLS)
+
+	conn, err := tls.Dial("tcp", l.Addr().String(), clientTLS)
+	c.Assert(err, jc.ErrorIsNil)
+	defer conn.Close()
+	_, err = conn.Write(rfc5424.FramingOctetCounting.Frame([]byte(testMessage)))
+	c.Assert(err, jc.ErrorIsNil)
+
+	msg := s.next(c)
+	c.Check(msg.String(), gc.Equals, testMessage)
+	c.Assert(msg.Conn.TLS, gc.NotNil)
+	c.Assert(msg.Conn.TLS.PeerCertificates, gc.HasLen, 1)
+	c.Check(msg.Conn.TLS.PeerCertificates[0].Equal(clientCert.Leaf), jc.IsTrue)
+}
+
+func (s *ServerSuite) TestServeTLSWithoutCertificates(c *gc.C) {
+	srv := s.newServer(c, s.config())
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = srv.ServeTLS(l, &tls.Config{})
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ServerSuite) TestParseError(c *gc.C) {
+	srv := s.newServer(c, s.config())
+	addr := s.serve(c, srv, "tcp")
+
+	conn, err := net.Dial("tcp", addr.String())
+	c.Assert(err, jc.ErrorIsNil)
+	defer conn.Close()
+	_, err = conn.Write([]byte("not syslog\n" + testMessage + "\n"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.nextErr(c)
+	c.Assert(err, gc.FitsTypeOf, &server.ParseError{})
+	c.Check(string(err.(*server.ParseError).Raw), gc.Equals, "not syslog")
+
+	// The connection is still usable.
+	msg := s.next(c)
+	c.Check(msg.String(), gc.Equals, testMessage)
+}
+
+func (s *ServerSuite) TestMaxMessageSize(c *gc.C) {
+	cfg := s.config()
+	cfg.MaxMessageSize = 20
+	srv := s.newServer(c, cfg)
+	addr := s.serve(c, srv, "tcp")
+
+	conn, err := net.Dial("tcp", addr.String())
+	c.Assert(err, jc.ErrorIsNil)
+	defer conn.Close()
+	_, err = conn.Write([]byte(testMessage + "\n"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.nextErr(c)
+	c.Check(errors.Cause(err), gc.Equals, rfc5424.ErrFrameTooLarge)
+	assertClosed(c, conn)
+}
+
+func (s *ServerSuite) TestIdleTimeout(c *gc.C) {
+	cfg := s.config()
+	cfg.IdleTimeout = 10 * time.Millisecond
+	srv := s.newServer(c, cfg)
+	addr := s.serve(c, srv, "tcp")
+
+	conn, err := net.Dial("tcp", addr.String())
+	c.Assert(err, jc.ErrorIsNil)
+	defer conn.Close()
+
+	err = s.nextErr(c)
+	c.Check(err, gc.ErrorMatches, ".*i/o timeout")
+	assertClosed(c, conn)
+}
+
+func (s *ServerSuite) TestMaxConnections(c *gc.C) {
+	cfg := s.config()
+	cfg.MaxConnections = 1
+	srv := s.newServer(c, cfg)
+	addr := s.serve(c, srv, "tcp")
+
+	first, err := net.Dial("tcp", addr.String())
+	c.Assert(err, jc.ErrorIsNil)
+	defer first.Close()
+	_, err = first.Write([]byte(testMessage + "\n"))
+	c.Assert(err, jc.ErrorIsNil)
+	s.next(c)
+
+	second, err := net.Dial("tcp", addr.String())
+	c.Assert(err, jc.ErrorIsNil)
+	defer second.Close()
+	assertClosed(c, second)
+}
+
+func (s *ServerSuite) TestShutdown(c *gc.C) {
+	srv := s.newServer(c, s.config())
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	served := make(chan error, 1)
+	go func() {
+		served <- srv.Serve(l)
+	}()
+
+	conn, err := net.Dial("tcp", l.Addr().String())
+	c.Assert(err, jc.ErrorIsNil)
+	defer conn.Close()
+	_, err = conn.Write([]byte(testMessage + "\n"))
+	c.Assert(err, jc.ErrorIsNil)
+	s.next(c)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	err = srv.Shutdown(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(<-served, gc.Equals, server.ErrServerClosed)
+	assertClosed(c, conn)
+	_, err = net.Dial("tcp", l.Addr().String())
+	c.Check(err, gc.NotNil)
+
+	err = srv.Serve(l)
+	c.Check(err, gc.Equals, server.ErrServerClosed)
+}
+
+func (s *ServerSuite) TestShutdownStuckHandler(c *gc.C) {
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	cfg := s.config()
+	cfg.Handler = server.HandlerFunc(func(server.Message) {
+		close(entered)
+		<-release
+	})
+	srv := s.newServer(c, cfg)
+	s.AddCleanup(func(*gc.C) { close(release) })
+	addr := s.serve(c, srv, "tcp")
+
+	conn, err := net.Dial("tcp", addr.String())
+	c.Assert(err, jc.ErrorIsNil)
+	defer conn.Close()
+	_, err = conn.Write([]byte(testMessage + "\n"))
+	c.Assert(err, jc.ErrorIsNil)
+	<-entered
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		done <- srv.Shutdown(ctx)
+	}()
+	select {
+	case err := <-done:
+		c.Check(err, gc.Equals, context.DeadlineExceeded)
+	case <-time.After(10 * time.Second):
+		c.Fatal("Shutdown did not return after the context expired")
+	}
+}
+
+func (s *ServerSuite) TestAcceptTemporaryError(c *gc.C) {
+	srv := s.newServer(c, s.config())
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	go srv.Serve(&flakyListener{Listener: l, failures: 2})
+
+	c.Check(s.nextErr(c), gc.ErrorMatches, "too many open files")
+	c.Check(s.nextErr(c), gc.ErrorMatches, "too many open files")
+
+	conn, err := net.Dial("tcp", l.Addr().String())
+	c.Assert(err, jc.ErrorIsNil)
+	defer conn.Close()
+	_, err = conn.Write([]byte(testMessage + "\n"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.next(c).Msg, gc.Equals, "a message")
+}
+
+// flakyListener fails to accept a number of times before accepting
+// connections.
+type flakyListener struct {
+	net.Listener
+	failures int
+}
+
+func (l *flakyListener) Accept() (net.Conn, error) {
+	if l.failures > 0 {
+		l.failures--
+		return nil, temporaryError{}
+	}
+	return l.Listener.Accept()
+}
+
+type temporaryError struct{}
+
+func (temporaryError) Error() string   { return "too many open files" }
+func (temporaryError) Timeout() bool   { return false }
+func (temporaryError) Temporary() bool { return true }
+
+func (s *ServerSuite) TestMessageJSON(c *gc.C) {
+	msg, err := rfc5424.ParseMessage(`<28>1 - a.b.org an-app - - - a message`)
+	c.Assert(err, jc.ErrorIsNil)
+	received := server.Message{
+		Message: msg,
+		Conn: server.ConnInfo{
+			Network:    "tcp",
+			LocalAddr:  &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 601},
+			RemoteAddr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 2), Port: 49152},
+		},
+		Framing: rfc5424.FramingOctetCounting,
+	}
+
+	data, err := json.Marshal(received)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(string(data), jc.JSONEquals, map[string]interface{}{
+		"facility":    "daemon",
+		"severity":    "warning",
+		"hostname":    "a.b.org",
+		"app-name":    "an-app",
+		"msg":         "a message",
+		"network":     "tcp",
+		"local-addr":  "10.0.0.1:601",
+		"remote-addr": "10.0.0.2:49152",
+		"framing":     "octet-counting",
+	})
+}
+
+func assertClosed(c *gc.C, conn net.Conn) {
+	conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+	_, err := conn.Read(make([]byte, 1))
+	c.Assert(err, gc.NotNil)
+	if netErr, ok := err.(net.Error); ok {
+		c.Assert(netErr.Timeout(), jc.IsFalse)
+	} else {
+		c.Assert(err, gc.Equals, io.EOF)
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package spool
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+
+	"github.com/juju/rfc/v2/rfc5424"
+)
+
+const (
+	// DefaultSegmentSize is the segment size used when
+	// Config.SegmentSize is not set.
+	DefaultSegmentSize = 16 << 20
+
+	// DefaultRetryInterval is the retry interval used when
+	// Config.RetryInterval is not set.
+	DefaultRetryInterval = time.Second
+)
+
+// SyncPolicy is when spooled messages are synced to disk.
+type SyncPolicy int
+
+const (
+	// SyncNever leaves syncing to the operating system. Messages
+	// survive the process stopping but not the machine crashing.
+	SyncNever SyncPolicy = iota
+
+	// SyncInterval syncs when a message is spooled if the last sync
+	// was at least Config.SyncInterval ago.
+	SyncInterval
+
+	// SyncAlways syncs every message before Send returns.
+	SyncAlways
+)
+
+// String returns the name of the sync policy.
+func (p SyncPolicy) String() string {
+	switch p {
+	case SyncNever:
+		return "never"
+	case SyncInterval:
+		return "interval"
+	case SyncAlways:
+		return "always"
+	default:
+		return fmt.Sprintf("SyncPolicy(%d)", int(p))
+	}
+}
+
+// Config is the configuration for a Spool.
+type Config struct {
+	// Dir is the directory that holds the spool. It is created if it
+	// does not exist. Only one Spool may use a directory at a time.
+	Dir string
+
+	// Upstream is where spooled messages are delivered. If it is an
+	// rfc5424.RawSender, messages are delivered exactly as they were
+	// spooled.
+	Upstream rfc5424.Sender
+
+	// SegmentSize is the size at which a new segment file is started.
+	// If not set, DefaultSegmentSize is used.
+	SegmentSize int64
+
+	// MaxSize, if set, limits the total size of the segment files.
+	// When spooling a message would exceed it, the oldest segments
+	// are removed, losing any undelivered messages in them. It must
+	// be at least SegmentSize.
+	MaxSize int64
+
+	// MaxAge, if set, is how long a message may wait to be delivered.
+	// Older messages are dropped instead.
+	MaxAge time.Duration
+
+	// Sync is when messages are synced to disk.
+	Sync SyncPolicy
+
+	// SyncInterval is the minimum time between syncs with the
+	// SyncInterval policy.
+	SyncInterval time.Duration
+
+	// RetryInterval is how long to wait after failing to deliver a
+	// message before trying again. If not set, DefaultRetryInterval
+	// is used.
+	RetryInterval time.Duration
+
+	// ErrorHandler, if set, is called with any error from delivering
+	// messages, and for any messages that are lost because they were
+	// corrupt, too old or removed to keep within MaxSize.
+	ErrorHandler func(error)
+
+	// Clock is used to timestamp messages and time retries. If it is
+	// nil then the wall clock is used.
+	Clock clock.Clock
+}
+
+// Validate ensures that the config is correct.
+func (cfg Config) Validate() error {
+	if cfg.Dir == "" {
+		return errors.NotValidf("empty Dir")
+	}
+	if cfg.Upstream == nil {
+		return errors.NotValidf("nil Upstream")
+	}
+	if cfg.SegmentSize < 0 {
+		return errors.NotValidf("negative SegmentSize")
+	}
+	if cfg.MaxSize < 0 {
+		return errors.NotValidf("negative MaxSize")
+	}
+	if cfg.MaxSize > 0 && cfg.MaxSize < cfg.segmentSize() {
+		return errors.NotValidf("MaxSize smaller than SegmentSize")
+	}
+	if cfg.MaxAge < 0 {
+		return errors.NotValidf("negative MaxAge")
+	}
+	switch cfg.Sync {
+	case SyncNever, SyncAlways:
+	case SyncInterval:
+		if cfg.SyncInterval <= 0 {
+			return errors.NotValidf("SyncInterval policy without SyncInterval")
+		}
+	default:
+		return errors.NotValidf("Sync %v", cfg.Sync)
+	}
+	if cfg.RetryInterval < 0 {
+		return errors.NotValidf("negative RetryInterval")
+	}
+	return nil
+}
+
+func (cfg Config) segmentSize() int64 {
+	if cfg.SegmentSize == 0 {
+		return DefaultSegmentSize
+	}
+	return cfg.SegmentSize
+}
@@ -0,0 +1,172 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package spool
+
+import (
+	"os"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/rfc/v2/rfc5424"
+)
+
+// loop delivers spooled messages until the spool is closed.
+func (s *Spool) loop() {
+	defer close(s.stopped)
+
+	var reader *os.File
+	defer func() {
+		if reader != nil {
+			reader.Close()
+		}
+	}()
+
+	expired := 0
+	for {
+		pos, more, err := s.nextPosition()
+		if err != nil {
+			s.report(errors.Trace(err))
+		}
+		if !more || err != nil {
+			if expired > 0 {
+				s.report(errors.Errorf("dropped %d messages older than %v", expired, s.cfg.MaxAge))
+				expired = 0
+			}
+			if !s.wait(err != nil) {
+				return
+			}
+			continue
+		}
+
+		if reader == nil || reader.Name() != segmentPath(s.cfg.Dir, pos.seq) {
+			if reader != nil {
+				reader.Close()
+			}
+			if reader, err = os.Open(segmentPath(s.cfg.Dir, pos.seq)); err != nil {
+				reader = nil
+				s.report(errors.Annotate(err, "reading spool"))
+				if !s.wait(true) {
+					return
+				}
+				continue
+			}
+		}
+		rec, size, err := readRecord(reader, pos.offset)
+		if err != nil {
+			// This can only happen if the segment was changed by
+			// something else; skip the rest of it.
+			s.report(errors.Annotatef(err, "reading spool segment %d", pos.seq))
+			s.skipSegment(pos)
+			continue
+		}
+		next := position{seq: pos.seq, offset: pos.offset + size}
+
+		if s.cfg.MaxAge > 0 && s.clock.Now().Sub(rec.spooled) > s.cfg.MaxAge {
+			expired++
+			s.advance(pos, next)
+			continue
+		}
+		if expired > 0 {
+			s.report(errors.Errorf("dropped %d messages older than %v", expired, s.cfg.MaxAge))
+			expired = 0
+		}
+
+		raw, err := rfc5424.ParseRawMessage(string(rec.payload))
+		if err != nil {
+			s.report(errors.Annotate(err, "dropping spooled message"))
+			s.advance(pos, next)
+			continue
+		}
+		if err := rfc5424.SendRaw(s.cfg.Upstream, raw); err != nil {
+			s.report(errors.Annotate(err, "delivering spooled message"))
+			if !s.wait(true) {
+				return
+			}
+			continue
+		}
+		s.advance(pos, next)
+	}
+}
+
+// wait blocks until there may be more messages to deliver, or for the
+// retry interval if retry is true. It returns false if the spool was
+// closed.
+func (s *Spool) wait(retry bool) bool {
+	if retry {
+		select {
+		case <-s.done:
+			return false
+		case <-s.clock.After(s.cfg.RetryInterval):
+			return true
+		}
+	}
+	select {
+	case <-s.done:
+		return false
+	case <-s.wake:
+		return true
+	}
+}
+
+// nextPosition returns the position of the next message to deliver,
+// moving on from any segment that has been completely delivered. It
+// returns false if there are no more messages.
+func (s *Spool) nextPosition() (position, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for {
+		seg := s.segments[0]
+		if s.read.offset < seg.size {
+			return s.read, true, nil
+		}
+		if len(s.segments) == 1 {
+			return position{}, false, nil
+		}
+		if err := os.Remove(segmentPath(s.cfg.Dir, seg.seq)); err != nil && !os.IsNotExist(err) {
+			return position{}, false, errors.Annotate(err, "removing delivered segment")
+		}
+		s.segments = s.segments[1:]
+		s.total -= seg.size
+		s.read = position{seq: s.segments[0].seq}
+		s.delivered = 0
+		if err := s.writeAck(); err != nil {
+			return position{}, false, errors.Trace(err)
+		}
+	}
+}
+
+// advance records that the message at pos has been dealt with, unless
+// the spool has moved on in the meantime, e.g. because its segment was
+// removed.
+func (s *Spool) advance(pos, next position) {
+	if err := s.moveRead(pos, next); err != nil {
+		s.report(errors.Trace(err))
+	}
+}
+
+func (s *Spool) moveRead(pos, next position) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.read != pos {
+		return nil
+	}
+	s.read = next
+	s.delivered++
+	s.pending--
+	return s.writeAck()
+}
+
+// skipSegment gives up on the rest of the segment containing pos.
+func (s *Spool) skipSegment(pos position) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.read != pos {
+		return
+	}
+	seg := s.segments[0]
+	s.pending -= seg.records - s.delivered
+	s.delivered = seg.records
+	s.read.offset = seg.size
+}
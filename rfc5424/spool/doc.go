@@ -0,0 +1,30 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+// The spool package provides a disk-backed spool for at-least-once
+// delivery of syslog messages. A Spool is an rfc5424.Sender that
+// appends each message to a segmented log in a directory and delivers
+// the messages to an upstream sender, in order, from a background
+// goroutine. Messages that cannot be delivered stay on disk, so they
+// survive the collector being unreachable and the process restarting.
+//
+// The upstream must reconnect after a failure for delivery to resume.
+// An *rfc5424.MultiClient does that, even with a single host:
+//
+//	upstream, err := rfc5424.OpenMulti(rfc5424.MultiClientConfig{
+//		Hosts: []string{"collector.example.com:514"},
+//	})
+//	sp, err := spool.Open(spool.Config{
+//		Dir:      "/var/spool/myapp/syslog",
+//		Upstream: upstream,
+//	})
+//
+// A message is sent again if the process stops between it being sent
+// and its delivery being recorded, so upstreams may see duplicates.
+//
+// On disk, each segment file holds a sequence of records, each with a
+// header giving its length, a CRC-32C checksum and the time it was
+// spooled. A separate file records the position of the next message
+// to deliver. When a spool is opened, each segment is checked and any
+// corrupt or partly written data is truncated.
+package spool
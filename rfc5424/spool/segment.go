@@ -0,0 +1,191 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package spool
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+const (
+	// segmentSuffix is the file name suffix of segment files.
+	segmentSuffix = ".seg"
+
+	// ackFile is the name of the file that holds the position of the
+	// next message to deliver.
+	ackFile = "ack"
+
+	// headerSize is the size of a record header: the length of the
+	// payload, its checksum and the time it was spooled.
+	headerSize = 16
+
+	// maxPayloadSize bounds the length in a record header, so that a
+	// corrupt one is not trusted.
+	maxPayloadSize = 1 << 24
+
+	// ackSize is the size of the ack file: the segment sequence
+	// number, the offset in it and a checksum of both.
+	ackSize = 20
+)
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// position identifies a record in the spool.
+type position struct {
+	seq    uint64
+	offset int64
+}
+
+// record is a spooled message.
+type record struct {
+	spooled time.Time
+	payload []byte
+}
+
+// encode returns the record in its on-disk form.
+func (rec record) encode() []byte {
+	data := make([]byte, headerSize+len(rec.payload))
+	binary.BigEndian.PutUint32(data[0:], uint32(len(rec.payload)))
+	binary.BigEndian.PutUint64(data[8:], uint64(rec.spooled.UnixNano()))
+	copy(data[headerSize:], rec.payload)
+	binary.BigEndian.PutUint32(data[4:], crc32.Checksum(data[8:], crcTable))
+	return data
+}
+
+// readRecord reads the record at the offset, returning its size on
+// disk. io.EOF is returned if there is no record there and
+// io.ErrUnexpectedEOF if there is only part of one.
+func readRecord(r io.ReaderAt, offset int64) (record, int64, error) {
+	var header [headerSize]byte
+	if n, err := r.ReadAt(header[:], offset); err != nil {
+		if err == io.EOF && n > 0 {
+			err = io.ErrUnexpectedEOF
+		}
+		return record{}, 0, err
+	}
+	length := binary.BigEndian.Uint32(header[0:])
+	if length > maxPayloadSize {
+		return record{}, 0, fmt.Errorf("bad record length %d", length)
+	}
+	data := make([]byte, 8+length)
+	copy(data, header[8:])
+	if _, err := r.ReadAt(data[8:], offset+headerSize); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return record{}, 0, err
+	}
+	if crc32.Checksum(data, crcTable) != binary.BigEndian.Uint32(header[4:]) {
+		return record{}, 0, fmt.Errorf("bad record checksum")
+	}
+	rec := record{
+		spooled: time.Unix(0, int64(binary.BigEndian.Uint64(data))),
+		payload: data[8:],
+	}
+	return rec, headerSize + int64(length), nil
+}
+
+// segment is one file of the spool.
+type segment struct {
+	seq  uint64
+	size int64
+
+	// records is the number of records in the segment.
+	records int
+}
+
+func segmentPath(dir string, seq uint64) string {
+	return filepath.Join(dir, fmt.Sprintf("%020d%s", seq, segmentSuffix))
+}
+
+// listSegments returns the sequence numbers of the segment files in
+// the directory, in order.
+func listSegments(dir string) ([]uint64, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var seqs []uint64
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasSuffix(name, segmentSuffix) {
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, segmentSuffix), 10, 64)
+		if err != nil {
+			continue
+		}
+		seqs = append(seqs, seq)
+	}
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+	return seqs, nil
+}
+
+// recoverSegment checks every record in the segment file. If it finds
+// a corrupt or partly written record, the file is truncated there and
+// the loss is reported.
+func recoverSegment(dir string, seq uint64, report func(error)) (*segment, error) {
+	path := segmentPath(dir, seq)
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	seg := &segment{seq: seq}
+	for seg.size < info.Size() {
+		_, size, err := readRecord(f, seg.size)
+		if err != nil {
+			report(errors.Errorf("truncating corrupt segment %q at offset %d, losing %d bytes: %v",
+				filepath.Base(path), seg.size, info.Size()-seg.size, err))
+			if err := f.Truncate(seg.size); err != nil {
+				return nil, errors.Trace(err)
+			}
+			return seg, nil
+		}
+		seg.size += size
+		seg.records++
+	}
+	return seg, nil
+}
+
+// readAck reads the ack file, returning false if there is none or it
+// is corrupt.
+func readAck(dir string) (position, bool) {
+	data, err := os.ReadFile(filepath.Join(dir, ackFile))
+	if err != nil || len(data) != ackSize {
+		return position{}, false
+	}
+	if crc32.Checksum(data[:16], crcTable) != binary.BigEndian.Uint32(data[16:]) {
+		return position{}, false
+	}
+	pos := position{
+		seq:    binary.BigEndian.Uint64(data[0:]),
+		offset: int64(binary.BigEndian.Uint64(data[8:])),
+	}
+	return pos, true
+}
+
+// encodeAck returns the contents of the ack file for the position.
+func encodeAck(pos position) []byte {
+	data := make([]byte, ackSize)
+	binary.BigEndian.PutUint64(data[0:], pos.seq)
+	binary.BigEndian.PutUint64(data[8:], uint64(pos.offset))
+	binary.BigEndian.PutUint32(data[16:], crc32.Checksum(data[:16], crcTable))
+	return data
+}
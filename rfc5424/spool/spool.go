@@ -0,0 +1,377 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package spool
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+
+	"github.com/juju/rfc/v2/rfc5424"
+)
+
+// Spool is an rfc5424.Sender that stores messages on disk and delivers
+// them to its upstream in the background. It is safe for concurrent
+// use.
+type Spool struct {
+	cfg   Config
+	clock clock.Clock
+
+	mu       sync.Mutex
+	closed   bool
+	segments []*segment
+	writer   *os.File
+	lastSync time.Time
+	ack      *os.File
+
+	// read is the position of the next message to deliver.
+	read position
+
+	// delivered is the number of records before read in its segment.
+	delivered int
+
+	// pending is the number of messages waiting to be delivered.
+	pending int
+
+	// total is the combined size of the segments.
+	total int64
+
+	wake    chan struct{}
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+var (
+	_ rfc5424.Sender    = (*Spool)(nil)
+	_ rfc5424.RawSender = (*Spool)(nil)
+)
+
+// Open opens the spool in the configured directory and starts
+// delivering any messages already in it.
+func Open(cfg Config) (*Spool, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if cfg.Clock == nil {
+		cfg.Clock = clock.WallClock
+	}
+	if cfg.RetryInterval == 0 {
+		cfg.RetryInterval = DefaultRetryInterval
+	}
+	if err := os.MkdirAll(cfg.Dir, 0700); err != nil {
+		return nil, errors.Annotate(err, "creating spool directory")
+	}
+
+	s := &Spool{
+		cfg:     cfg,
+		clock:   cfg.Clock,
+		wake:    make(chan struct{}, 1),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	if err := s.recover(); err != nil {
+		s.closeFiles()
+		return nil, errors.Trace(err)
+	}
+	go s.loop()
+	return s, nil
+}
+
+// recover loads the segments in the directory, checking them for
+// corruption, and opens the files for writing.
+func (s *Spool) recover() error {
+	seqs, err := listSegments(s.cfg.Dir)
+	if err != nil {
+		return errors.Annotate(err, "listing segments")
+	}
+	ack, haveAck := readAck(s.cfg.Dir)
+	for _, seq := range seqs {
+		if haveAck && seq < ack.seq {
+			// Every message in it was delivered.
+			if err := os.Remove(segmentPath(s.cfg.Dir, seq)); err != nil {
+				return errors.Trace(err)
+			}
+			continue
+		}
+		seg, err := recoverSegment(s.cfg.Dir, seq, s.report)
+		if err != nil {
+			return errors.Annotatef(err, "recovering segment %d", seq)
+		}
+		s.segments = append(s.segments, seg)
+		s.total += seg.size
+		s.pending += seg.records
+	}
+
+	if len(s.segments) == 0 {
+		seq := uint64(1)
+		if haveAck {
+			seq = ack.seq
+		}
+		if err := s.createSegment(seq); err != nil {
+			return errors.Trace(err)
+		}
+	} else {
+		last := s.segments[len(s.segments)-1]
+		f, err := os.OpenFile(segmentPath(s.cfg.Dir, last.seq), os.O_WRONLY|os.O_APPEND, 0)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		s.writer = f
+	}
+
+	first := s.segments[0]
+	s.read = position{seq: first.seq}
+	if haveAck && ack.seq == first.seq {
+		if err := s.skipDelivered(first, ack.offset); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	f, err := os.OpenFile(filepath.Join(s.cfg.Dir, ackFile), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	s.ack = f
+	return errors.Trace(s.writeAck())
+}
+
+// skipDelivered moves the read position to the first record at or
+// after the offset in the segment.
+func (s *Spool) skipDelivered(seg *segment, offset int64) error {
+	f, err := os.Open(segmentPath(s.cfg.Dir, seg.seq))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	for s.read.offset < offset && s.read.offset < seg.size {
+		_, size, err := readRecord(f, s.read.offset)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		s.read.offset += size
+		s.delivered++
+		s.pending--
+	}
+	return nil
+}
+
+// Send implements rfc5424.Sender. The message is validated and
+// spooled; it is delivered later. Messages over 16 MiB are rejected.
+func (s *Spool) Send(msg rfc5424.Message) error {
+	if err := msg.Validate(); err != nil {
+		return errors.NewNotValid(err, "message")
+	}
+	return errors.Trace(s.spool(msg.String()))
+}
+
+// SendRaw implements rfc5424.RawSender. The parsed message is spooled
+// as it was received (see rfc5424.RawMessage.Verbatim), and delivered
+// as it was received if the upstream is an rfc5424.RawSender.
+func (s *Spool) SendRaw(raw *rfc5424.RawMessage) error {
+	if err := raw.Message.Validate(); err != nil {
+		return errors.NewNotValid(err, "message")
+	}
+	return errors.Trace(s.spool(raw.String()))
+}
+
+// spool stores the payload for delivery.
+func (s *Spool) spool(payload string) error {
+	if len(payload) > maxPayloadSize {
+		return errors.NotValidf("message of %d bytes", len(payload))
+	}
+
+	lost, err := s.append(payload)
+	if lost > 0 {
+		s.report(errors.Errorf("spool full, dropped %d undelivered messages", lost))
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// append spools the payload, returning the number of undelivered
+// messages removed to make room for it.
+func (s *Spool) append(payload string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return 0, errors.New("spool closed")
+	}
+
+	rec := record{spooled: s.clock.Now(), payload: []byte(payload)}
+	data := rec.encode()
+	last := s.segments[len(s.segments)-1]
+	if last.size > 0 && last.size+int64(len(data)) > s.cfg.segmentSize() {
+		if err := s.createSegment(last.seq + 1); err != nil {
+			return 0, errors.Trace(err)
+		}
+		last = s.segments[len(s.segments)-1]
+	}
+	lost := 0
+	for s.cfg.MaxSize > 0 && s.total+int64(len(data)) > s.cfg.MaxSize && len(s.segments) > 1 {
+		n, err := s.removeOldest()
+		lost += n
+		if err != nil {
+			return lost, errors.Trace(err)
+		}
+	}
+
+	if _, err := writeRecord(s.writer, data); err != nil {
+		s.undoWrite(last)
+		return lost, errors.Annotate(err, "spooling message")
+	}
+	last.size += int64(len(data))
+	last.records++
+	s.total += int64(len(data))
+	s.pending++
+
+	now := s.clock.Now()
+	switch {
+	case s.cfg.Sync == SyncAlways,
+		s.cfg.Sync == SyncInterval && now.Sub(s.lastSync) >= s.cfg.SyncInterval:
+		if err := s.writer.Sync(); err != nil {
+			return lost, errors.Annotate(err, "syncing spool")
+		}
+		s.lastSync = now
+	}
+	return lost, nil
+}
+
+// writeRecord writes an encoded record to the segment being written.
+// It is a variable so that tests can make writes fail.
+var writeRecord = func(f *os.File, data []byte) (int, error) {
+	return f.Write(data)
+}
+
+// undoWrite removes anything that a failed write left at the end of
+// the segment, so that the next record is written where the segment's
+// size says it is. If that fails then a new segment is started, and
+// the partial record is never read.
+func (s *Spool) undoWrite(seg *segment) {
+	err := s.writer.Truncate(seg.size)
+	if err == nil {
+		return
+	}
+	s.report(errors.Annotate(err, "removing partial record"))
+	if err := s.createSegment(seg.seq + 1); err != nil {
+		s.report(errors.Trace(err))
+	}
+}
+
+// createSegment starts a new segment for writing.
+func (s *Spool) createSegment(seq uint64) error {
+	if s.writer != nil {
+		if s.cfg.Sync != SyncNever {
+			if err := s.writer.Sync(); err != nil {
+				return errors.Annotate(err, "syncing spool")
+			}
+		}
+		if err := s.writer.Close(); err != nil {
+			return errors.Trace(err)
+		}
+		s.writer = nil
+	}
+	f, err := os.OpenFile(segmentPath(s.cfg.Dir, seq), os.O_WRONLY|os.O_APPEND|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return errors.Annotate(err, "creating segment")
+	}
+	s.writer = f
+	s.segments = append(s.segments, &segment{seq: seq})
+	return nil
+}
+
+// removeOldest removes the oldest segment, which must not be the one
+// being written, returning the number of undelivered messages in it.
+func (s *Spool) removeOldest() (int, error) {
+	seg := s.segments[0]
+	if err := os.Remove(segmentPath(s.cfg.Dir, seg.seq)); err != nil {
+		return 0, errors.Trace(err)
+	}
+	s.segments = s.segments[1:]
+	s.total -= seg.size
+
+	lost := seg.records
+	if s.read.seq == seg.seq {
+		lost -= s.delivered
+		s.read = position{seq: s.segments[0].seq}
+		s.delivered = 0
+	}
+	s.pending -= lost
+	return lost, errors.Trace(s.writeAck())
+}
+
+// writeAck records the read position.
+func (s *Spool) writeAck() error {
+	if _, err := s.ack.WriteAt(encodeAck(s.read), 0); err != nil {
+		return errors.Annotate(err, "recording delivery")
+	}
+	if s.cfg.Sync == SyncAlways {
+		if err := s.ack.Sync(); err != nil {
+			return errors.Annotate(err, "syncing delivery")
+		}
+	}
+	return nil
+}
+
+// Pending returns the number of messages waiting to be delivered.
+func (s *Spool) Pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pending
+}
+
+// Close stops delivering messages and closes the spool. Messages that
+// have not been delivered are kept for when the spool is next opened.
+// The upstream is not closed.
+func (s *Spool) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	s.mu.Unlock()
+
+	close(s.done)
+	<-s.stopped
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var err error
+	if s.cfg.Sync != SyncNever {
+		err = s.writer.Sync()
+	}
+	if closeErr := s.closeFiles(); err == nil {
+		err = closeErr
+	}
+	return errors.Trace(err)
+}
+
+func (s *Spool) closeFiles() error {
+	var err error
+	for _, f := range []*os.File{s.writer, s.ack} {
+		if f == nil {
+			continue
+		}
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+func (s *Spool) report(err error) {
+	if s.cfg.ErrorHandler != nil {
+		s.cfg.ErrorHandler(err)
+	}
+}
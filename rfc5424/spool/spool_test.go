@@ -0,0 +1,453 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package spool_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/rfc/v2/rfc5424"
+	"github.com/juju/rfc/v2/rfc5424/rfc5424test"
+	"github.com/juju/rfc/v2/rfc5424/spool"
+)
+
+const longWait = 10 * time.Second
+
+type SpoolSuite struct {
+	testing.IsolationSuite
+
+	dir      string
+	upstream *upstreamSender
+	errs     chan error
+}
+
+var _ = gc.Suite(&SpoolSuite{})
+
+func (s *SpoolSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.dir = filepath.Join(c.MkDir(), "spool")
+	s.upstream = &upstreamSender{
+		sent:    make(chan rfc5424.Message, 100),
+		rawSent: make(chan string, 100),
+	}
+	s.errs = make(chan error, 100)
+}
+
+func (s *SpoolSuite) config() spool.Config {
+	return spool.Config{
+		Dir:           s.dir,
+		Upstream:      s.upstream,
+		RetryInterval: 10 * time.Millisecond,
+		ErrorHandler: func(err error) {
+			select {
+			case s.errs <- err:
+			default:
+			}
+		},
+	}
+}
+
+func (s *SpoolSuite) open(c *gc.C, cfg spool.Config) *spool.Spool {
+	sp, err := spool.Open(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { sp.Close() })
+	return sp
+}
+
+func (s *SpoolSuite) send(c *gc.C, sp *spool.Spool, texts ...string) {
+	for _, text := range texts {
+		err := sp.Send(newMessage(text))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *SpoolSuite) next(c *gc.C) string {
+	select {
+	case msg := <-s.upstream.sent:
+		return msg.Msg
+	case <-time.After(longWait):
+		c.Fatal("timed out waiting for message")
+	}
+	panic("unreachable")
+}
+
+func (s *SpoolSuite) nextErr(c *gc.C) error {
+	select {
+	case err := <-s.errs:
+		return err
+	case <-time.After(longWait):
+		c.Fatal("timed out waiting for error")
+	}
+	panic("unreachable")
+}
+
+func (s *SpoolSuite) waitPending(c *gc.C, sp *spool.Spool, n int) {
+	deadline := time.Now().Add(longWait)
+	for sp.Pending() != n {
+		if time.Now().After(deadline) {
+			c.Fatalf("still %d pending, expected %d", sp.Pending(), n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func newMessage(text string) rfc5424.Message {
+	return rfc5424.Message{
+		Header: rfc5424.Header{
+			Priority: rfc5424.Priority{
+				Severity: rfc5424.SeverityWarning,
+				Facility: rfc5424.FacilityDaemon,
+			},
+			AppName: "an-app",
+		},
+		Msg: text,
+	}
+}
+
+func (s *SpoolSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		change func(*spool.Config)
+		err    string
+	}{{
+		change: func(cfg *spool.Config) { cfg.Dir = "" },
+		err:    "empty Dir not valid",
+	}, {
+		change: func(cfg *spool.Config) { cfg.Upstream = nil },
+		err:    "nil Upstream not valid",
+	}, {
+		change: func(cfg *spool.Config) { cfg.SegmentSize = -1 },
+		err:    "negative SegmentSize not valid",
+	}, {
+		change: func(cfg *spool.Config) { cfg.SegmentSize, cfg.MaxSize = 1000, 999 },
+		err:    "MaxSize smaller than SegmentSize not valid",
+	}, {
+		change: func(cfg *spool.Config) { cfg.MaxAge = -time.Second },
+		err:    "negative MaxAge not valid",
+	}, {
+		change: func(cfg *spool.Config) { cfg.Sync = spool.SyncInterval },
+		err:    "SyncInterval policy without SyncInterval not valid",
+	}, {
+		change: func(cfg *spool.Config) { cfg.Sync = 7 },
+		err:    `Sync SyncPolicy\(7\) not valid`,
+	}} {
+		c.Logf("test %d", i)
+		cfg := s.config()
+		test.change(&cfg)
+
+		_, err := spool.Open(cfg)
+
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *SpoolSuite) TestDeliver(c *gc.C) {
+	recorder := rfc5424test.NewRecorder()
+	srv := rfc5424test.NewServer(recorder)
+	srv.Start()
+	defer srv.Close()
+	upstream, err := rfc5424.OpenMulti(rfc5424.MultiClientConfig{
+		Hosts: []string{srv.Addr().String()},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer upstream.Close()
+	cfg := s.config()
+	cfg.Upstream = upstream
+	cfg.Sync = spool.SyncAlways
+	sp := s.open(c, cfg)
+
+	s.send(c, sp, "one", "two", "three")
+
+	received, err := recorder.WaitForN(3, longWait)
+	c.Assert(err, jc.ErrorIsNil)
+	for i, text := range []string{"one", "two", "three"} {
+		c.Check(received[i].Parsed.Msg, gc.Equals, text)
+	}
+	s.waitPending(c, sp, 0)
+}
+
+func (s *SpoolSuite) TestSendVerbatim(c *gc.C) {
+	sp := s.open(c, s.config())
+	text := `<28>1 1970-01-01T16:05:21+01:00 a.b.org an-app 119 - [spam x="\\y"] a message`
+	raw, err := rfc5424.ParseRawMessage(text)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = sp.SendRaw(raw)
+	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case sent := <-s.upstream.rawSent:
+		c.Check(sent, gc.Equals, text)
+	case <-time.After(longWait):
+		c.Fatal("timed out waiting for message")
+	}
+}
+
+func (s *SpoolSuite) TestSendInvalid(c *gc.C) {
+	sp := s.open(c, s.config())
+
+	err := sp.Send(rfc5424.Message{Header: rfc5424.Header{AppName: "-"}})
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(sp.Pending(), gc.Equals, 0)
+}
+
+func (s *SpoolSuite) TestSendTooLarge(c *gc.C) {
+	sp := s.open(c, s.config())
+	msg := newMessage(strings.Repeat("x", 1<<24))
+
+	err := sp.Send(msg)
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `message of \d+ bytes not valid`)
+	c.Check(sp.Pending(), gc.Equals, 0)
+
+	// Later messages are unaffected.
+	s.send(c, sp, "one")
+	c.Check(s.next(c), gc.Equals, "one")
+}
+
+func (s *SpoolSuite) TestRetry(c *gc.C) {
+	s.upstream.setErr(errors.New("collector down"))
+	sp := s.open(c, s.config())
+
+	s.send(c, sp, "one", "two")
+	c.Check(s.nextErr(c), gc.ErrorMatches, "delivering spooled message: collector down")
+	c.Check(sp.Pending(), gc.Equals, 2)
+	s.upstream.setErr(nil)
+
+	c.Check(s.next(c), gc.Equals, "one")
+	c.Check(s.next(c), gc.Equals, "two")
+	s.waitPending(c, sp, 0)
+}
+
+func (s *SpoolSuite) TestReplayAfterRestart(c *gc.C) {
+	s.upstream.setErr(errors.New("collector down"))
+	sp, err := spool.Open(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	s.send(c, sp, "one", "two", "three")
+	err = sp.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.upstream.setErr(nil)
+	sp = s.open(c, s.config())
+
+	c.Check(s.next(c), gc.Equals, "one")
+	c.Check(s.next(c), gc.Equals, "two")
+	c.Check(s.next(c), gc.Equals, "three")
+	s.waitPending(c, sp, 0)
+}
+
+func (s *SpoolSuite) TestDeliveredNotReplayed(c *gc.C) {
+	sp, err := spool.Open(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	s.send(c, sp, "one", "two")
+	c.Check(s.next(c), gc.Equals, "one")
+	c.Check(s.next(c), gc.Equals, "two")
+	s.waitPending(c, sp, 0)
+	err = sp.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	sp = s.open(c, s.config())
+	s.send(c, sp, "three")
+
+	c.Check(s.next(c), gc.Equals, "three")
+}
+
+func (s *SpoolSuite) TestSegments(c *gc.C) {
+	s.upstream.setErr(errors.New("collector down"))
+	cfg := s.config()
+	// Each record is about 45 bytes, so every segment holds one.
+	cfg.SegmentSize = 60
+	sp := s.open(c, cfg)
+
+	s.send(c, sp, "one", "two", "three", "four", "five")
+	c.Check(s.segmentFiles(c), gc.HasLen, 5)
+	s.upstream.setErr(nil)
+
+	for _, text := range []string{"one", "two", "three", "four", "five"} {
+		c.Check(s.next(c), gc.Equals, text)
+	}
+	s.waitPending(c, sp, 0)
+	c.Check(s.segmentFiles(c), gc.HasLen, 1)
+}
+
+func (s *SpoolSuite) TestMaxSize(c *gc.C) {
+	s.upstream.setErr(errors.New("collector down"))
+	cfg := s.config()
+	cfg.SegmentSize = 60
+	cfg.MaxSize = 150
+	sp := s.open(c, cfg)
+
+	s.send(c, sp, "one", "two", "three", "four", "five")
+
+	c.Check(s.segmentFiles(c), gc.HasLen, 3)
+	c.Check(sp.Pending(), gc.Equals, 3)
+	var dropped []string
+	for len(dropped) < 2 {
+		err := s.nextErr(c)
+		if err.Error() == "spool full, dropped 1 undelivered messages" {
+			dropped = append(dropped, err.Error())
+		}
+	}
+	s.upstream.setErr(nil)
+	c.Check(s.next(c), gc.Equals, "three")
+}
+
+func (s *SpoolSuite) TestMaxAge(c *gc.C) {
+	clock := testclock.NewClock(time.Unix(54321, 0))
+	s.upstream.setErr(errors.New("collector down"))
+	cfg := s.config()
+	cfg.MaxAge = time.Hour
+	cfg.RetryInterval = time.Minute
+	cfg.Clock = clock
+	sp := s.open(c, cfg)
+
+	s.send(c, sp, "one", "two")
+	c.Check(s.nextErr(c), gc.ErrorMatches, "delivering spooled message: collector down")
+	s.upstream.setErr(nil)
+	err := clock.WaitAdvance(2*time.Hour, longWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.nextErr(c), gc.ErrorMatches, "dropped 2 messages older than 1h0m0s")
+	s.send(c, sp, "three")
+	c.Check(s.next(c), gc.Equals, "three")
+}
+
+func (s *SpoolSuite) TestCorruptionRecovery(c *gc.C) {
+	s.upstream.setErr(errors.New("collector down"))
+	sp, err := spool.Open(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	s.send(c, sp, "one", "two")
+	err = sp.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	files := s.segmentFiles(c)
+	c.Assert(files, gc.HasLen, 1)
+	f, err := os.OpenFile(files[0], os.O_WRONLY|os.O_APPEND, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = f.Write([]byte("\x00\x00\x00\x20partial"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(f.Close(), jc.ErrorIsNil)
+
+	s.drainErrs()
+	s.upstream.setErr(nil)
+	sp = s.open(c, s.config())
+
+	c.Check(s.nextErr(c), gc.ErrorMatches, `truncating corrupt segment ".*\.seg" at offset \d+, losing 11 bytes: unexpected EOF`)
+	c.Check(s.next(c), gc.Equals, "one")
+	c.Check(s.next(c), gc.Equals, "two")
+	s.send(c, sp, "three")
+	c.Check(s.next(c), gc.Equals, "three")
+}
+
+func (s *SpoolSuite) TestCorruptAckFile(c *gc.C) {
+	sp, err := spool.Open(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	s.send(c, sp, "one")
+	c.Check(s.next(c), gc.Equals, "one")
+	s.waitPending(c, sp, 0)
+	err = sp.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	err = os.WriteFile(filepath.Join(s.dir, "ack"), []byte("garbage"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.open(c, s.config())
+
+	// Delivery is at least once, so the message is sent again.
+	c.Check(s.next(c), gc.Equals, "one")
+}
+
+func (s *SpoolSuite) TestPartialWrite(c *gc.C) {
+	failed := false
+	s.PatchValue(spool.WriteRecord, func(f *os.File, data []byte) (int, error) {
+		if failed {
+			return f.Write(data)
+		}
+		failed = true
+		n, _ := f.Write(data[:len(data)/2])
+		return n, errors.New("no space left on device")
+	})
+	sp := s.open(c, s.config())
+
+	err := sp.Send(newMessage("one"))
+	c.Check(err, gc.ErrorMatches, "spooling message: no space left on device")
+	s.send(c, sp, "two", "three")
+
+	c.Check(s.next(c), gc.Equals, "two")
+	c.Check(s.next(c), gc.Equals, "three")
+	select {
+	case err := <-s.errs:
+		c.Fatalf("unexpected error: %v", err)
+	default:
+	}
+}
+
+func (s *SpoolSuite) TestSendAfterClose(c *gc.C) {
+	sp, err := spool.Open(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	err = sp.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = sp.Send(newMessage("one"))
+
+	c.Check(err, gc.ErrorMatches, "spool closed")
+}
+
+func (s *SpoolSuite) segmentFiles(c *gc.C) []string {
+	files, err := filepath.Glob(filepath.Join(s.dir, "*.seg"))
+	c.Assert(err, jc.ErrorIsNil)
+	return files
+}
+
+func (s *SpoolSuite) drainErrs() {
+	for {
+		select {
+		case <-s.errs:
+		default:
+			return
+		}
+	}
+}
+
+type upstreamSender struct {
+	mu   sync.Mutex
+	err  error
+	sent chan rfc5424.Message
+
+	// rawSent holds the text of each message sent with SendRaw.
+	rawSent chan string
+}
+
+func (u *upstreamSender) setErr(err error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.err = err
+}
+
+func (u *upstreamSender) Send(msg rfc5424.Message) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.err != nil {
+		return u.err
+	}
+	u.sent <- msg
+	return nil
+}
+
+func (u *upstreamSender) SendRaw(raw *rfc5424.RawMessage) error {
+	if err := u.Send(raw.Message); err != nil {
+		return err
+	}
+	u.rawSent <- raw.String()
+	return nil
+}
@@ -24,6 +24,96 @@ func (sd StructuredData) String() string {
 	return strings.Join(elems, "")
 }
 
+// ParseStructuredData converts the RFC 5424 representation of
+// structured data back into StructuredData. Each element is returned
+// as a GenericStructuredDataElement.
+func ParseStructuredData(str string) (StructuredData, error) {
+	sd, rest, err := parseStructuredData(str)
+	if err != nil {
+		return nil, err
+	}
+	if rest != "" {
+		return nil, fmt.Errorf("unexpected trailing data %q", rest)
+	}
+	return sd, sd.Validate()
+}
+
+func parseStructuredData(str string) (StructuredData, string, error) {
+	if strings.HasPrefix(str, "-") {
+		return nil, str[1:], nil
+	}
+	if !strings.HasPrefix(str, "[") {
+		return nil, "", fmt.Errorf("expected %q or %q", "-", "[")
+	}
+
+	var sd StructuredData
+	for strings.HasPrefix(str, "[") {
+		elem, rest, err := parseStructuredDataElement(str[1:])
+		if err != nil {
+			return nil, "", fmt.Errorf("element %d not valid: %v", len(sd), err)
+		}
+		sd = append(sd, elem)
+		str = rest
+	}
+	return sd, str, nil
+}
+
+func parseStructuredDataElement(str string) (GenericStructuredDataElement, string, error) {
+	var elem GenericStructuredDataElement
+
+	end := strings.IndexAny(str, " ]")
+	if end <= 0 {
+		return elem, "", fmt.Errorf("missing ID")
+	}
+	elem.SDID = StructuredDataName(str[:end])
+	str = str[end:]
+
+	for strings.HasPrefix(str, " ") {
+		param, rest, err := parseStructuredDataParam(str[1:])
+		if err != nil {
+			return elem, "", fmt.Errorf("param %d not valid: %v", len(elem.Data), err)
+		}
+		elem.Data = append(elem.Data, param)
+		str = rest
+	}
+
+	if !strings.HasPrefix(str, "]") {
+		return elem, "", fmt.Errorf("missing %q", "]")
+	}
+	return elem, str[1:], nil
+}
+
+func parseStructuredDataParam(str string) (StructuredDataParam, string, error) {
+	var param StructuredDataParam
+
+	eq := strings.Index(str, `="`)
+	if eq <= 0 {
+		return param, "", fmt.Errorf("missing Name")
+	}
+	param.Name = StructuredDataName(str[:eq])
+	str = str[eq+2:]
+
+	var value strings.Builder
+	for i := 0; i < len(str); i++ {
+		switch c := str[i]; c {
+		case '\\':
+			// Only \", \\ and \] are escapes; any other backslash
+			// is kept as-is.
+			if i+1 < len(str) && strings.IndexByte(`"\]`, str[i+1]) >= 0 {
+				i++
+				c = str[i]
+			}
+			value.WriteByte(c)
+		case '"':
+			param.Value = StructuredDataParamValue(value.String())
+			return param, str[i+1:], nil
+		default:
+			value.WriteByte(c)
+		}
+	}
+	return param, "", fmt.Errorf("unterminated Value for %q", param.Name)
+}
+
 // Validate ensures that the structured data is correct.
 func (sd StructuredData) Validate() error {
 	for i, elem := range sd {
@@ -47,6 +137,36 @@ type StructuredDataElement interface {
 	Validate() error
 }
 
+// GenericStructuredDataElement is a structured data element that is
+// not tied to a specific SD-ID. It is what ParseStructuredData
+// produces, since the parser cannot know the concrete types that the
+// elements were created from.
+type GenericStructuredDataElement struct {
+	// SDID is the element's "SD-ID".
+	SDID StructuredDataName
+
+	// Data is the element's list of items, in order.
+	Data []StructuredDataParam
+}
+
+// ID returns the SD-ID for this element.
+func (sde GenericStructuredDataElement) ID() StructuredDataName {
+	return sde.SDID
+}
+
+// Params returns the []SD-PARAM for this element.
+func (sde GenericStructuredDataElement) Params() []StructuredDataParam {
+	params := make([]StructuredDataParam, len(sde.Data))
+	copy(params, sde.Data)
+	return params
+}
+
+// Validate ensures that the element is correct. The ID and params are
+// checked by StructuredData.Validate.
+func (sde GenericStructuredDataElement) Validate() error {
+	return nil
+}
+
 func structuredDataElementString(sde StructuredDataElement) string {
 	params := sde.Params()
 	if len(params) == 0 {
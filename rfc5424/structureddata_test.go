@@ -166,6 +166,66 @@ func (s *StructuredDataSuite) TestValidateBadElementBadParam(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `element 0 not valid: param 1 not valid: empty Name`)
 }
 
+func (s *StructuredDataSuite) TestParseStructuredData(c *gc.C) {
+	sd, err := rfc5424.ParseStructuredData(`[spam question="???" answer="42"][eggs][ham foo="a \"b\] \\c \d"]`)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(sd, jc.DeepEquals, rfc5424.StructuredData{
+		rfc5424.GenericStructuredDataElement{
+			SDID: "spam",
+			Data: []rfc5424.StructuredDataParam{
+				{Name: "question", Value: "???"},
+				{Name: "answer", Value: "42"},
+			},
+		},
+		rfc5424.GenericStructuredDataElement{
+			SDID: "eggs",
+		},
+		rfc5424.GenericStructuredDataElement{
+			SDID: "ham",
+			Data: []rfc5424.StructuredDataParam{
+				{Name: "foo", Value: `a "b] \c \d`},
+			},
+		},
+	})
+}
+
+func (s *StructuredDataSuite) TestParseStructuredDataNilValue(c *gc.C) {
+	sd, err := rfc5424.ParseStructuredData("-")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(sd, gc.HasLen, 0)
+}
+
+func (s *StructuredDataSuite) TestParseStructuredDataErrors(c *gc.C) {
+	for i, test := range []struct {
+		str string
+		err string
+	}{{
+		str: "",
+		err: `expected "-" or "\["`,
+	}, {
+		str: "[]",
+		err: `element 0 not valid: missing ID`,
+	}, {
+		str: `[spam x="y"`,
+		err: `element 0 not valid: missing "\]"`,
+	}, {
+		str: `[spam x]`,
+		err: `element 0 not valid: param 0 not valid: missing Name`,
+	}, {
+		str: `[spam x="y"] `,
+		err: `unexpected trailing data " "`,
+	}, {
+		str: `[sp=am x="y"]`,
+		err: `element 0 not valid: invalid ID "sp=am": invalid character`,
+	}} {
+		c.Logf("test %d: %q", i, test.str)
+		_, err := rfc5424.ParseStructuredData(test.str)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
 type stubElement struct {
 	stub *testing.Stub
 
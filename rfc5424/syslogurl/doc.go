@@ -0,0 +1,54 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+// The syslogurl package opens syslog destinations described by URLs,
+// so that they can be configured as strings, e.g.
+//
+//	tls://collector:6514?framing=octet&timeout=5s&ca=/etc/ssl/syslog-ca.pem
+//
+// The scheme selects the transport:
+//
+//	udp       UDP, port 514 by default
+//	tcp       TCP, port 601 by default
+//	tls       TLS over TCP, port 6514 by default
+//	unix      a Unix stream socket, e.g. unix:///dev/log-stream
+//	unixgram  a Unix datagram socket, e.g. unixgram:///dev/log
+//	relp      RELP (see the relp package); the port is required
+//	http      HTTP POST of batched messages (see the httpsyslog package)
+//	https     as http, over TLS
+//
+// Query parameters set options. They are accepted by all schemes:
+//
+//	timeout=5s     the dial timeout and rfc5424.ClientConfig.SendTimeout;
+//	               for http and https, the request timeout, which is
+//	               otherwise httpsyslog.DefaultRequestTimeout
+//	maxsize=8192   rfc5424.ClientConfig.MaxSize
+//
+// by tcp, tls and unix:
+//
+//	framing=octet  octet-counting framing, or "lf" for non-transparent
+//
+// by tls and https:
+//
+//	ca=PATH         a PEM file of the CA certificates to trust, instead
+//	                of the system's
+//	cert=PATH       with key, a PEM client certificate
+//	key=PATH        the client certificate's private key
+//	servername=NAME the name to verify the server's certificate for
+//	insecure=true   do not verify the server's certificate
+//
+// by relp:
+//
+//	window=N  relp.ClientConfig.Window
+//
+// and by http and https:
+//
+//	gzip=true  compress requests
+//	token=T    a bearer token; a user name and password may instead
+//	           be given in the URL for basic authentication
+//	batch=N    httpsyslog.Config.MaxBatchMessages
+//	flush=1s   httpsyslog.Config.FlushInterval
+//
+// Any other parameters of an http or https URL are left in the URL
+// that is posted to. For other schemes they are an error.
+package syslogurl
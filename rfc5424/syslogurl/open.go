@@ -0,0 +1,147 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package syslogurl
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"os"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/rfc/v2/rfc5424"
+	"github.com/juju/rfc/v2/rfc5424/httpsyslog"
+	"github.com/juju/rfc/v2/rfc5424/relp"
+)
+
+// Destination is a syslog destination opened by OpenURL.
+type Destination interface {
+	rfc5424.Sender
+
+	// Close closes the destination, first sending any messages it
+	// holds, for those that batch or wait for acknowledgements.
+	Close() error
+}
+
+// OpenURL opens the syslog destination described by the URL, as in
+// the package documentation. The URL's query parameters override the
+// corresponding fields of the config.
+func OpenURL(rawURL string, cfg rfc5424.ClientConfig) (Destination, error) {
+	dest, err := parseURL(rawURL)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if dest.timeout > 0 {
+		cfg.SendTimeout = dest.timeout
+	}
+	if dest.maxSize > 0 {
+		cfg.MaxSize = dest.maxSize
+	}
+	if dest.hasFraming {
+		cfg.Framing = dest.framing
+	}
+
+	switch dest.scheme {
+	case "tls":
+		tlsConfig, err := dest.tlsConfig()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		dial, err := rfc5424.TLSDialFunc(tlsConfig, dest.timeout)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return open(dest.address, cfg, dial)
+	case "relp":
+		dial := func(network, address string) (net.Conn, error) {
+			return net.DialTimeout(network, address, dest.timeout)
+		}
+		client, err := relp.Open(dest.address, relp.ClientConfig{
+			ClientConfig: cfg,
+			Window:       dest.window,
+		}, dial)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return client, nil
+	case "http", "https":
+		return dest.openHTTP(cfg)
+	default:
+		// The Client dials "tcp", so the network comes from the
+		// scheme instead.
+		network := dest.scheme
+		dial := func(_, address string) (rfc5424.Conn, error) {
+			return net.DialTimeout(network, address, dest.timeout)
+		}
+		return open(dest.address, cfg, dial)
+	}
+}
+
+func open(address string, cfg rfc5424.ClientConfig, dial rfc5424.DialFunc) (Destination, error) {
+	client, err := rfc5424.Open(address, cfg, dial)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return client, nil
+}
+
+func (dest *destination) openHTTP(cfg rfc5424.ClientConfig) (Destination, error) {
+	httpClient := &http.Client{Timeout: httpsyslog.DefaultRequestTimeout}
+	if dest.timeout > 0 {
+		httpClient.Timeout = dest.timeout
+	}
+	if dest.scheme == "https" {
+		tlsConfig, err := dest.tlsConfig()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = tlsConfig
+		httpClient.Transport = transport
+	}
+	client, err := httpsyslog.New(httpsyslog.Config{
+		ClientConfig:     cfg,
+		URL:              dest.httpURL,
+		HTTPClient:       httpClient,
+		BearerToken:      dest.token,
+		Username:         dest.user,
+		Password:         dest.password,
+		Gzip:             dest.gzip,
+		MaxBatchMessages: dest.batch,
+		FlushInterval:    dest.flush,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return client, nil
+}
+
+// tlsConfig returns the TLS config for the ca, cert, key, servername
+// and insecure parameters.
+func (dest *destination) tlsConfig() (*tls.Config, error) {
+	cfg := &tls.Config{
+		ServerName:         dest.serverName,
+		InsecureSkipVerify: dest.insecure,
+	}
+	if dest.ca != "" {
+		data, err := os.ReadFile(dest.ca)
+		if err != nil {
+			return nil, errors.Annotate(err, "reading CA certificates")
+		}
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM(data) {
+			return nil, errors.NotValidf("CA file %q without certificates", dest.ca)
+		}
+	}
+	if dest.cert != "" {
+		cert, err := tls.LoadX509KeyPair(dest.cert, dest.key)
+		if err != nil {
+			return nil, errors.Annotate(err, "loading client certificate")
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
@@ -0,0 +1,251 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package syslogurl_test
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/rfc/v2/rfc5424"
+	"github.com/juju/rfc/v2/rfc5424/rfc5424test"
+	"github.com/juju/rfc/v2/rfc5424/server"
+	"github.com/juju/rfc/v2/rfc5424/syslogurl"
+)
+
+const longWait = 10 * time.Second
+
+type OpenSuite struct {
+	testing.IsolationSuite
+
+	received chan rfc5424test.Message
+}
+
+var _ = gc.Suite(&OpenSuite{})
+
+func (s *OpenSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.received = make(chan rfc5424test.Message, 10)
+}
+
+func (s *OpenSuite) handler() rfc5424test.Handler {
+	return rfc5424test.HandlerFunc(func(msg rfc5424test.Message) {
+		s.received <- msg
+	})
+}
+
+func (s *OpenSuite) next(c *gc.C) rfc5424test.Message {
+	select {
+	case msg := <-s.received:
+		return msg
+	case <-time.After(longWait):
+		c.Fatalf("timed out waiting for message")
+	}
+	panic("unreachable")
+}
+
+// send opens the URL, sends a message and closes the destination.
+func (s *OpenSuite) send(c *gc.C, url string) {
+	dest, err := syslogurl.OpenURL(url, rfc5424.ClientConfig{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = dest.Send(newMessage())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(dest.Close(), jc.ErrorIsNil)
+}
+
+func newMessage() rfc5424.Message {
+	return rfc5424.Message{
+		Header: rfc5424.Header{
+			Priority: rfc5424.Priority{
+				Severity: rfc5424.SeverityWarning,
+				Facility: rfc5424.FacilityDaemon,
+			},
+			AppName: "an-app",
+		},
+		Msg: "a message",
+	}
+}
+
+func writePEM(c *gc.C, path, blockType string, der []byte) string {
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	err := os.WriteFile(path, data, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	return path
+}
+
+func (s *OpenSuite) TestTCP(c *gc.C) {
+	srv := rfc5424test.NewServer(s.handler())
+	srv.Start()
+	defer srv.Close()
+
+	s.send(c, "tcp://"+srv.Addr().String()+"?framing=octet&timeout=5s")
+	msg := s.next(c)
+	c.Check(msg.Parsed.Msg, gc.Equals, "a message")
+	c.Check(msg.Framing, gc.Equals, rfc5424.FramingOctetCounting)
+}
+
+func (s *OpenSuite) TestMaxSize(c *gc.C) {
+	srv := rfc5424test.NewServer(s.handler())
+	srv.Start()
+	defer srv.Close()
+
+	s.send(c, "tcp://"+srv.Addr().String()+"?maxsize=20")
+	c.Check(s.next(c).Message, gc.Equals, newMessage().String()[:20])
+}
+
+func (s *OpenSuite) TestUDP(c *gc.C) {
+	srv := rfc5424test.NewUDPServer(s.handler())
+	srv.Start()
+	defer srv.Close()
+
+	s.send(c, "udp://"+srv.Addr().String())
+	c.Check(s.next(c).Parsed.Msg, gc.Equals, "a message")
+}
+
+func (s *OpenSuite) TestTLS(c *gc.C) {
+	ca := rfc5424test.NewCA()
+	serverTLS, _ := ca.TLSConfigs(rfc5424test.TLSOptions{Mutual: true})
+	srv := rfc5424test.NewServer(s.handler())
+	srv.TLS = serverTLS
+	srv.StartTLS()
+	defer srv.Close()
+
+	dir := c.MkDir()
+	clientCert := ca.ClientCert("client")
+	keyDER, err := x509.MarshalPKCS8PrivateKey(clientCert.PrivateKey)
+	c.Assert(err, jc.ErrorIsNil)
+	query := url.Values{
+		"ca":   {writePEM(c, filepath.Join(dir, "ca.pem"), "CERTIFICATE", ca.Cert.Raw)},
+		"cert": {writePEM(c, filepath.Join(dir, "cert.pem"), "CERTIFICATE", clientCert.Certificate[0])},
+		"key":  {writePEM(c, filepath.Join(dir, "key.pem"), "PRIVATE KEY", keyDER)},
+	}
+
+	s.send(c, "tls://"+srv.Addr().String()+"?"+query.Encode())
+	c.Check(s.next(c).Parsed.Msg, gc.Equals, "a message")
+}
+
+func (s *OpenSuite) TestTLSUntrusted(c *gc.C) {
+	serverTLS, _ := rfc5424test.NewTLSConfigs(rfc5424test.TLSOptions{})
+	srv := rfc5424test.NewServer(s.handler())
+	srv.TLS = serverTLS
+	srv.StartTLS()
+	defer srv.Close()
+
+	_, err := syslogurl.OpenURL("tls://"+srv.Addr().String(), rfc5424.ClientConfig{})
+	c.Check(err, gc.ErrorMatches, "dialing TLS: .*certificate signed by unknown authority")
+
+	s.send(c, "tls://"+srv.Addr().String()+"?insecure=true")
+	c.Check(s.next(c).Parsed.Msg, gc.Equals, "a message")
+}
+
+func (s *OpenSuite) TestBadCAFile(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "ca.pem")
+	err := os.WriteFile(path, []byte("not a certificate"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = syslogurl.OpenURL("tls://127.0.0.1?ca="+path, rfc5424.ClientConfig{})
+	c.Check(err, gc.ErrorMatches, fmt.Sprintf("CA file %q without certificates not valid", path))
+}
+
+func (s *OpenSuite) TestUnix(c *gc.C) {
+	received := make(chan server.Message, 2)
+	srv, err := server.New(server.Config{
+		Handler: server.HandlerFunc(func(msg server.Message) {
+			received <- msg
+		}),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer srv.Close()
+	dir := c.MkDir()
+	stream, err := net.Listen("unix", filepath.Join(dir, "stream.sock"))
+	c.Assert(err, jc.ErrorIsNil)
+	go srv.Serve(stream)
+	datagram, err := net.ListenPacket("unixgram", filepath.Join(dir, "datagram.sock"))
+	c.Assert(err, jc.ErrorIsNil)
+	go srv.ServePacket(datagram)
+
+	s.send(c, "unix://"+stream.Addr().String()+"?framing=octet")
+	s.send(c, "unixgram://"+datagram.LocalAddr().String())
+	// The two sockets are served independently, so the messages may
+	// arrive in either order.
+	byNetwork := make(map[string]server.Message)
+	for i := 0; i < 2; i++ {
+		select {
+		case msg := <-received:
+			byNetwork[msg.Conn.Network] = msg
+		case <-time.After(longWait):
+			c.Fatalf("timed out waiting for message")
+		}
+	}
+	c.Assert(byNetwork, gc.HasLen, 2)
+	c.Check(byNetwork["unix"].Msg, gc.Equals, "a message")
+	c.Check(byNetwork["unix"].Framing, gc.Equals, rfc5424.FramingOctetCounting)
+	c.Check(byNetwork["unixgram"].Msg, gc.Equals, "a message")
+}
+
+func (s *OpenSuite) TestRELP(c *gc.C) {
+	srv := rfc5424test.NewRELPServer(s.handler())
+	srv.Start()
+	defer srv.Close()
+
+	s.send(c, "relp://"+srv.Addr().String()+"?window=4")
+	c.Check(s.next(c).Parsed.Msg, gc.Equals, "a message")
+}
+
+func (s *OpenSuite) TestHTTP(c *gc.C) {
+	requests := make(chan *http.Request, 1)
+	bodies := make(chan string, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		requests <- req
+		bodies <- string(body)
+	}))
+	defer srv.Close()
+
+	s.send(c, srv.URL+"/ingest?token=abc&source=web")
+	req := <-requests
+	c.Check(req.URL.String(), gc.Equals, "/ingest?source=web")
+	c.Check(req.Header.Get("Authorization"), gc.Equals, "Bearer abc")
+	c.Check(<-bodies, gc.Equals, string(rfc5424.FramingOctetCounting.Frame([]byte(newMessage().String()))))
+}
+
+func (s *OpenSuite) TestHTTPS(c *gc.C) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		username, password, _ := req.BasicAuth()
+		if username != "user" || password != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer srv.Close()
+	caPath := writePEM(c, filepath.Join(c.MkDir(), "ca.pem"), "CERTIFICATE", srv.Certificate().Raw)
+	u, err := url.Parse(srv.URL)
+	c.Assert(err, jc.ErrorIsNil)
+	u.User = url.UserPassword("user", "pass")
+	u.RawQuery = url.Values{"ca": {caPath}}.Encode()
+
+	s.send(c, u.String())
+
+	u.User = url.UserPassword("user", "wrong")
+	dest, err := syslogurl.OpenURL(u.String(), rfc5424.ClientConfig{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(dest.Send(newMessage()), jc.ErrorIsNil)
+	c.Check(dest.Close(), gc.ErrorMatches, `posting 1 messages: unexpected response "401 Unauthorized"`)
+}
+
+func (s *OpenSuite) TestOpenInvalid(c *gc.C) {
+	_, err := syslogurl.OpenURL("tcp://collector?window=4", rfc5424.ClientConfig{})
+	c.Check(err, gc.ErrorMatches, `syslog URL parameter "window" for tcp not valid`)
+}
@@ -0,0 +1,271 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package syslogurl
+
+import (
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/rfc/v2/rfc5424"
+)
+
+// The default ports of the schemes that have one.
+const (
+	DefaultUDPPort = "514"
+	DefaultTCPPort = "601"
+	DefaultTLSPort = "6514"
+)
+
+// schemes maps each scheme to the query parameters it accepts, beyond
+// those accepted by every scheme.
+var schemes = map[string][]string{
+	"tcp":      {"framing"},
+	"udp":      nil,
+	"tls":      {"framing", "ca", "cert", "key", "servername", "insecure"},
+	"unix":     {"framing"},
+	"unixgram": nil,
+	"relp":     {"window"},
+	"http":     {"gzip", "token", "batch", "flush"},
+	"https":    {"gzip", "token", "batch", "flush", "ca", "cert", "key", "servername", "insecure"},
+}
+
+// commonParams are accepted by every scheme.
+var commonParams = []string{"timeout", "maxsize"}
+
+// destination is a parsed URL.
+type destination struct {
+	scheme string
+
+	// address is the host and port, or the socket path for unix and
+	// unixgram.
+	address string
+
+	// httpURL is the URL to post to for http and https, without the
+	// parameters that were used.
+	httpURL string
+
+	timeout time.Duration
+	maxSize int
+	window  int
+
+	// framing is only used if hasFraming is set.
+	framing    rfc5424.Framing
+	hasFraming bool
+
+	ca, cert, key string
+	serverName    string
+	insecure      bool
+
+	gzip     bool
+	token    string
+	batch    int
+	flush    time.Duration
+	user     string
+	password string
+}
+
+// ValidateURL reports whether the URL is a valid syslog destination,
+// as accepted by OpenURL, without connecting to it. Files named by the
+// URL are not read.
+func ValidateURL(rawURL string) error {
+	_, err := parseURL(rawURL)
+	return errors.Trace(err)
+}
+
+func parseURL(rawURL string) (*destination, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errors.NewNotValid(err, "syslog URL")
+	}
+	allowed, ok := schemes[u.Scheme]
+	if !ok {
+		return nil, errors.NotValidf("syslog URL scheme %q", u.Scheme)
+	}
+	dest := &destination{scheme: u.Scheme}
+	if err := dest.setAddress(u); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	query := u.Query()
+	params := make([]string, 0, len(query))
+	for name := range query {
+		params = append(params, name)
+	}
+	sort.Strings(params)
+	passThrough := url.Values{}
+	for _, name := range params {
+		values := query[name]
+		if !contains(commonParams, name) && !contains(allowed, name) {
+			if u.Scheme == "http" || u.Scheme == "https" {
+				// Anything else is for the HTTP endpoint.
+				passThrough[name] = values
+				continue
+			}
+			return nil, errors.NotValidf("syslog URL parameter %q for %s", name, u.Scheme)
+		}
+		if len(values) != 1 {
+			return nil, errors.NotValidf("repeated syslog URL parameter %q", name)
+		}
+		if err := dest.setParam(name, values[0]); err != nil {
+			return nil, errors.NewNotValid(err, "syslog URL parameter "+strconv.Quote(name))
+		}
+	}
+	if (dest.cert == "") != (dest.key == "") {
+		return nil, errors.NotValidf("syslog URL with only one of cert and key")
+	}
+	if dest.token != "" && dest.user != "" {
+		return nil, errors.NotValidf("syslog URL with both token and user")
+	}
+
+	if u.Scheme == "http" || u.Scheme == "https" {
+		post := *u
+		post.User = nil
+		post.RawQuery = passThrough.Encode()
+		post.Fragment = ""
+		dest.httpURL = post.String()
+	}
+	return dest, nil
+}
+
+// setAddress sets the address from the URL's host or path.
+func (dest *destination) setAddress(u *url.URL) error {
+	if u.Fragment != "" {
+		return errors.NotValidf("syslog URL with fragment")
+	}
+	switch dest.scheme {
+	case "unix", "unixgram":
+		if u.Host != "" {
+			return errors.NotValidf("%s URL with host %q", dest.scheme, u.Host)
+		}
+		if u.Path == "" {
+			return errors.NotValidf("%s URL without socket path", dest.scheme)
+		}
+		dest.address = u.Path
+		return nil
+	case "http", "https":
+		if u.Host == "" {
+			return errors.NotValidf("%s URL without host", dest.scheme)
+		}
+		if u.User != nil {
+			dest.user = u.User.Username()
+			dest.password, _ = u.User.Password()
+		}
+		return nil
+	}
+
+	if u.Host == "" {
+		return errors.NotValidf("%s URL without host", dest.scheme)
+	}
+	if u.User != nil {
+		return errors.NotValidf("%s URL with user", dest.scheme)
+	}
+	if u.Path != "" && u.Path != "/" {
+		return errors.NotValidf("%s URL with path %q", dest.scheme, u.Path)
+	}
+	port := u.Port()
+	if port == "" {
+		switch dest.scheme {
+		case "udp":
+			port = DefaultUDPPort
+		case "tcp":
+			port = DefaultTCPPort
+		case "tls":
+			port = DefaultTLSPort
+		default:
+			// RELP has no standard port.
+			return errors.NotValidf("%s URL without port", dest.scheme)
+		}
+	} else if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+		return errors.NotValidf("syslog URL port %q", port)
+	}
+	dest.address = net.JoinHostPort(u.Hostname(), port)
+	return nil
+}
+
+// setParam sets the value of a query parameter.
+func (dest *destination) setParam(name, value string) error {
+	var err error
+	switch name {
+	case "timeout":
+		dest.timeout, err = parseDuration(value)
+	case "flush":
+		dest.flush, err = parseDuration(value)
+	case "maxsize":
+		dest.maxSize, err = parseCount(value)
+	case "window":
+		dest.window, err = parseCount(value)
+	case "batch":
+		dest.batch, err = parseCount(value)
+	case "framing":
+		dest.framing, err = parseFraming(value)
+		dest.hasFraming = true
+	case "insecure":
+		dest.insecure, err = strconv.ParseBool(value)
+	case "gzip":
+		dest.gzip, err = strconv.ParseBool(value)
+	case "ca":
+		dest.ca, err = parsePath(value)
+	case "cert":
+		dest.cert, err = parsePath(value)
+	case "key":
+		dest.key, err = parsePath(value)
+	case "servername":
+		dest.serverName = value
+	case "token":
+		dest.token = value
+	}
+	return err
+}
+
+func parseDuration(value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, errors.Errorf("duration %q not positive", value)
+	}
+	return d, nil
+}
+
+func parseCount(value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		return 0, errors.Errorf("%q not a positive number", value)
+	}
+	return n, nil
+}
+
+func parseFraming(value string) (rfc5424.Framing, error) {
+	switch strings.ToLower(value) {
+	case "octet", "octet-counting":
+		return rfc5424.FramingOctetCounting, nil
+	case "lf", "non-transparent":
+		return rfc5424.FramingNonTransparent, nil
+	default:
+		return 0, errors.Errorf("unknown framing %q", value)
+	}
+}
+
+func parsePath(value string) (string, error) {
+	if value == "" {
+		return "", errors.New("empty path")
+	}
+	return value, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
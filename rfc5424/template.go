@@ -0,0 +1,209 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package rfc5424
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+)
+
+// hostnameLookupTimeout bounds the DNS lookups done by LocalHostname.
+const hostnameLookupTimeout = time.Second
+
+// MessageTemplate holds default values for messages. The template's
+// Header.Timestamp is not used; messages are stamped using Clock.
+type MessageTemplate struct {
+	Header
+
+	// StructuredData holds elements that are added to every message.
+	StructuredData StructuredData
+
+	// Clock provides the timestamp for messages. If it is nil then
+	// no timestamp is set.
+	Clock clock.Clock
+}
+
+// NewMessageTemplate returns a template populated for the current
+// process: the hostname comes from os.Hostname, the AppName from
+// os.Args[0] and the ProcID from the process ID. The facility is
+// "user", the severity "informational" and the clock is the wall
+// clock.
+//
+// The hostname is not looked up, since that may block. To follow the
+// full preference order of RFC 5424 section 6.2.4, set Hostname from
+// LocalHostname. To add an Origin element for the running program as
+// well, use sdelements.BuildInfoTemplate instead.
+func NewMessageTemplate() MessageTemplate {
+	var tmpl MessageTemplate
+	tmpl.Facility = FacilityUser
+	tmpl.Severity = SeverityInformational
+	tmpl.Hostname = osHostname()
+	if len(os.Args) > 0 {
+		tmpl.AppName = processAppName(os.Args[0])
+	}
+	tmpl.ProcID = ProcID(strconv.Itoa(os.Getpid()))
+	tmpl.Clock = clock.WallClock
+	return tmpl
+}
+
+// Message returns a new message with the given text, populated from
+// the template.
+func (t MessageTemplate) Message(text string) Message {
+	msg := Message{
+		Header: t.Header,
+		Msg:    text,
+	}
+	msg.Timestamp = Timestamp{}
+	return t.Apply(msg)
+}
+
+// Apply returns a copy of the message with any empty fields set from
+// the template. The template's structured data elements are added
+// unless the message already has an element with the same ID. Since
+// the zero value of Severity is "emergency", the severity is never
+// changed.
+func (t MessageTemplate) Apply(msg Message) Message {
+	return t.applyStructuredData(t.applyHeader(msg))
+}
+
+// ApplyRaw returns a copy of the parsed message with the template's
+// structured data added, as for Apply. Its header is left alone, even
+// where it has the NILVALUE, so that it is still sent as it was
+// received (see RawMessage.Verbatim).
+func (t MessageTemplate) ApplyRaw(raw *RawMessage) *RawMessage {
+	applied := *raw
+	applied.Message = t.applyStructuredData(raw.Message)
+	return &applied
+}
+
+// applyStructuredData adds the template's structured data elements to
+// the message, unless it already has an element with the same ID.
+func (t MessageTemplate) applyStructuredData(msg Message) Message {
+	if len(t.StructuredData) > 0 {
+		sd := make(StructuredData, len(msg.StructuredData), len(msg.StructuredData)+len(t.StructuredData))
+		copy(sd, msg.StructuredData)
+		for _, element := range t.StructuredData {
+			if !hasElement(msg.StructuredData, element.ID()) {
+				sd = append(sd, element)
+			}
+		}
+		msg.StructuredData = sd
+	}
+	return msg
+}
+
+// applyHeader sets the empty header fields of the message from the
+// template.
+func (t MessageTemplate) applyHeader(msg Message) Message {
+	if msg.Facility == facilityDefault {
+		msg.Facility = t.Facility
+	}
+	if msg.Timestamp.IsZero() && t.Clock != nil {
+		msg.Timestamp = Timestamp{t.Clock.Now()}
+	}
+	if msg.Hostname.String() == "-" {
+		msg.Hostname = t.Hostname
+	}
+	if msg.AppName == "" {
+		msg.AppName = t.AppName
+	}
+	if msg.ProcID == "" {
+		msg.ProcID = t.ProcID
+	}
+	if msg.MsgID == "" {
+		msg.MsgID = t.MsgID
+	}
+	return msg
+}
+
+func hasElement(sd StructuredData, id StructuredDataName) bool {
+	for _, element := range sd {
+		if element.ID() == id {
+			return true
+		}
+	}
+	return false
+}
+
+// processAppName converts a program path into a valid AppName.
+func processAppName(path string) AppName {
+	name := strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return '_'
+		}
+		return r
+	}, filepath.Base(path))
+	if len(name) > 48 {
+		name = name[:48]
+	}
+	return AppName(name)
+}
+
+// osHostname returns the name of the local machine as reported by the
+// operating system, as the FQDN if it is qualified.
+func osHostname() Hostname {
+	name, err := os.Hostname()
+	switch {
+	case err != nil || name == "":
+		return Hostname{}
+	case strings.Contains(name, "."):
+		return Hostname{FQDN: name}
+	default:
+		return Hostname{Hostname: name}
+	}
+}
+
+// LocalHostname returns the hostname of the local machine, populated
+// so that the preferred value is used, as described by RFC 5424
+// section 6.2.4: the FQDN if it can be found, then a static IP
+// address, then the hostname and then a dynamic IP address. On Linux
+// an address is static if the kernel holds it as permanent, i.e. it
+// was not assigned by DHCP or autoconfiguration; elsewhere every
+// interface address is taken to be dynamic.
+//
+// An unqualified name is looked up in DNS, which may take up to a
+// second, so the result is best found once and kept.
+func LocalHostname() Hostname {
+	h := osHostname()
+	if h.Hostname != "" {
+		h.FQDN = lookupFQDN(h.Hostname)
+	}
+	if h.FQDN == "" {
+		h.StaticIP, h.DynamicIP = interfaceIPs()
+	}
+	return h
+}
+
+// lookupFQDN finds the fully-qualified name for the host by resolving
+// it and then looking up the names for its addresses. It returns an
+// empty string if there is none.
+func lookupFQDN(name string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), hostnameLookupTimeout)
+	defer cancel()
+
+	addrs, err := net.DefaultResolver.LookupHost(ctx, name)
+	if err != nil {
+		return ""
+	}
+	for _, addr := range addrs {
+		names, err := net.DefaultResolver.LookupAddr(ctx, addr)
+		if err != nil {
+			continue
+		}
+		for _, fqdn := range names {
+			fqdn = strings.TrimSuffix(fqdn, ".")
+			if strings.HasPrefix(fqdn, name+".") {
+				return fqdn
+			}
+		}
+	}
+	return ""
+}
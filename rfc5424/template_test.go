@@ -0,0 +1,150 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package rfc5424_test
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/rfc/v2/rfc5424"
+)
+
+type MessageTemplateSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&MessageTemplateSuite{})
+
+func (s *MessageTemplateSuite) TestNewMessageTemplate(c *gc.C) {
+	tmpl := rfc5424.NewMessageTemplate()
+
+	c.Check(tmpl.Priority, jc.DeepEquals, rfc5424.Priority{
+		Severity: rfc5424.SeverityInformational,
+		Facility: rfc5424.FacilityUser,
+	})
+	name, err := os.Hostname()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(tmpl.Hostname.String(), gc.Equals, name)
+	c.Check(string(tmpl.AppName), gc.Equals, filepath.Base(os.Args[0]))
+	c.Check(string(tmpl.ProcID), gc.Equals, strconv.Itoa(os.Getpid()))
+	c.Check(tmpl.Clock, gc.NotNil)
+
+	msg := tmpl.Message("a message")
+	c.Check(msg.Validate(), jc.ErrorIsNil)
+	c.Check(msg.Timestamp.IsZero(), jc.IsFalse)
+}
+
+func (s *MessageTemplateSuite) TestLocalHostname(c *gc.C) {
+	name, err := os.Hostname()
+	c.Assert(err, jc.ErrorIsNil)
+
+	hostname := rfc5424.LocalHostname()
+
+	c.Check(hostname.Validate(), jc.ErrorIsNil)
+	if hostname.FQDN != "" {
+		c.Check(hostname.FQDN, gc.Matches, name+`(\..*)?`)
+		return
+	}
+	c.Check(hostname.Hostname, gc.Equals, name)
+	for _, ip := range []net.IP{hostname.StaticIP, hostname.DynamicIP} {
+		if ip != nil {
+			c.Check(ip.IsGlobalUnicast(), jc.IsTrue)
+		}
+	}
+	// A static address is preferred to the unqualified name.
+	if hostname.StaticIP != nil {
+		c.Check(hostname.String(), gc.Equals, hostname.StaticIP.String())
+	}
+}
+
+func (s *MessageTemplateSuite) TestMessage(c *gc.C) {
+	var tmpl rfc5424.MessageTemplate
+	tmpl.Severity = rfc5424.SeverityWarning
+	tmpl.Facility = rfc5424.FacilityDaemon
+	tmpl.Timestamp = rfc5424.Timestamp{time.Unix(1, 0)}
+	tmpl.Hostname = rfc5424.Hostname{FQDN: "a.b.org"}
+	tmpl.AppName = "an-app"
+	tmpl.ProcID = "119"
+	tmpl.MsgID = "xyz..."
+	tmpl.StructuredData = rfc5424.StructuredData{
+		newStubElement(&testing.Stub{}, "spam", "x=y"),
+	}
+	tmpl.Clock = testclock.NewClock(time.Unix(54321, 123).UTC())
+
+	msg := tmpl.Message("a message")
+
+	c.Check(msg.String(), gc.Equals, `<28>1 1970-01-01T15:05:21.000000123Z a.b.org an-app 119 xyz... [spam x="y"] a message`)
+}
+
+func (s *MessageTemplateSuite) TestApply(c *gc.C) {
+	var tmpl rfc5424.MessageTemplate
+	tmpl.Severity = rfc5424.SeverityDebug
+	tmpl.Facility = rfc5424.FacilityDaemon
+	tmpl.Hostname = rfc5424.Hostname{FQDN: "a.b.org"}
+	tmpl.AppName = "an-app"
+	tmpl.ProcID = "119"
+	tmpl.StructuredData = rfc5424.StructuredData{
+		newStubElement(&testing.Stub{}, "spam", "x=y"),
+		newStubElement(&testing.Stub{}, "eggs", "x=y"),
+	}
+	tmpl.Clock = testclock.NewClock(time.Unix(54321, 123).UTC())
+	msg := rfc5424.Message{
+		Header: rfc5424.Header{
+			Priority: rfc5424.Priority{
+				Severity: rfc5424.SeverityWarning,
+			},
+			Hostname: rfc5424.Hostname{Hostname: "other"},
+			MsgID:    "xyz...",
+		},
+		StructuredData: rfc5424.StructuredData{
+			newStubElement(&testing.Stub{}, "spam", "z=w"),
+		},
+		Msg: "a message",
+	}
+
+	applied := tmpl.Apply(msg)
+
+	c.Check(applied.String(), gc.Equals, `<28>1 1970-01-01T15:05:21.000000123Z other an-app 119 xyz... [spam z="w"][eggs x="y"] a message`)
+	c.Check(msg.StructuredData, gc.HasLen, 1)
+}
+
+func (s *MessageTemplateSuite) TestApplyZeroValue(c *gc.C) {
+	var tmpl rfc5424.MessageTemplate
+	msg := rfc5424.Message{Msg: "a message"}
+
+	c.Check(tmpl.Apply(msg), jc.DeepEquals, msg)
+}
+
+func (s *MessageTemplateSuite) TestApplyRaw(c *gc.C) {
+	var tmpl rfc5424.MessageTemplate
+	tmpl.Hostname = rfc5424.Hostname{FQDN: "a.b.org"}
+	tmpl.AppName = "an-app"
+	tmpl.StructuredData = rfc5424.StructuredData{
+		newStubElement(&testing.Stub{}, "spam", "x=y"),
+	}
+	tmpl.Clock = testclock.NewClock(time.Unix(54321, 123).UTC())
+	raw, err := rfc5424.ParseRawMessage("<165>1 - - - - - - a message")
+	c.Assert(err, jc.ErrorIsNil)
+
+	applied := tmpl.ApplyRaw(raw)
+
+	// The header is left as received, so the message is still sent
+	// verbatim, with the element appended.
+	str, ok := applied.Verbatim()
+	c.Check(ok, jc.IsTrue)
+	c.Check(str, gc.Equals, `<165>1 - - - - - [spam x="y"] a message`)
+	c.Check(raw.Message.StructuredData, gc.HasLen, 0)
+
+	// Apply fills in the header.
+	msg := tmpl.Apply(raw.Message)
+	c.Check(msg.String(), gc.Equals, `<165>1 1970-01-01T15:05:21.000000123Z a.b.org an-app - - [spam x="y"] a message`)
+}
@@ -0,0 +1,174 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package rfc5424
+
+import (
+	"bytes"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/juju/errors"
+)
+
+// Writer is an io.Writer that sends what is written to it as syslog
+// messages. It allows the standard library's log.Logger, and anything
+// else that only knows about io.Writer, to log through a Client:
+//
+//	w := rfc5424.NewWriter(client, rfc5424.Header{AppName: "myapp"})
+//	logger := log.New(w, "", 0)
+//
+// Writer is safe for concurrent use.
+type Writer struct {
+	sender   Sender
+	template Header
+
+	mu             sync.Mutex
+	structuredData StructuredData
+	splitLines     bool
+	maxLineLength  int
+	partial        []byte
+}
+
+// DefaultMaxLineLength is the maximum line length set by NewWriter.
+const DefaultMaxLineLength = 64 * 1024
+
+// NewWriter returns a Writer that sends messages to the sender,
+// typically a *Client. Every message gets the template's header,
+// except that the timestamp is set to the time of the write. Each
+// line is sent as a separate message, and lines are limited to
+// DefaultMaxLineLength bytes.
+func NewWriter(sender Sender, template Header) *Writer {
+	return &Writer{
+		sender:        sender,
+		template:      template,
+		splitLines:    true,
+		maxLineLength: DefaultMaxLineLength,
+	}
+}
+
+// SetStructuredData sets structured data to include in every message.
+func (w *Writer) SetStructuredData(sd StructuredData) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.structuredData = sd
+}
+
+// SetSplitLines sets whether each line written is a separate message,
+// which it is by default. Data after the last newline is held back
+// until the line is completed or Flush is called. If it is not set
+// then each call to Write results in exactly one message, without any
+// trailing newline.
+func (w *Writer) SetSplitLines(split bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.splitLines = split
+}
+
+// SetMaxLineLength limits how much of a line is held back when lines
+// are split. Once that many bytes have been written without a newline
+// they are sent as a message of their own, cut short if necessary so
+// that a UTF-8 encoded character is not split. Zero means no limit.
+func (w *Writer) SetMaxLineLength(max int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.maxLineLength = max
+}
+
+// Write implements io.Writer. Empty lines are not sent.
+//
+// If sending a line fails, the count returned covers only the lines
+// that were sent, so that writing the rest of the data again does not
+// send any line twice.
+func (w *Writer) Write(data []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.splitLines {
+		if err := w.send(bytes.TrimSuffix(data, []byte("\n"))); err != nil {
+			return 0, errors.Trace(err)
+		}
+		return len(data), nil
+	}
+
+	// The first held bytes were consumed by earlier writes.
+	held := len(w.partial)
+	w.partial = append(w.partial, data...)
+	start := 0
+	for {
+		rest := w.partial[start:]
+		end := bytes.IndexByte(rest, '\n')
+		next := end + 1
+		if max := w.maxLineLength; max > 0 && (end < 0 || end > max) && len(rest) >= max {
+			cut := runeBoundary(rest, max)
+			end, next = cut, cut
+		}
+		if end < 0 {
+			break
+		}
+		if err := w.send(rest[:end]); err != nil {
+			// Keep back the failed line only as far as it was
+			// consumed by earlier writes; the rest of it is left to
+			// the caller.
+			n := 0
+			if start > held {
+				n = start - held
+			}
+			w.partial = holdBack(w.partial[start : held+n])
+			return n, errors.Trace(err)
+		}
+		start += next
+	}
+	w.partial = holdBack(w.partial[start:])
+	return len(data), nil
+}
+
+// runeBoundary returns where to cut the data, at or before max bytes,
+// so that a UTF-8 encoded character is not split, including one that
+// has only partly been written. If there is nowhere to cut, e.g. max
+// is less than the length of the first character, max is returned.
+func runeBoundary(data []byte, max int) int {
+	start := max - 1
+	for start > 0 && start > max-utf8.UTFMax && !utf8.RuneStart(data[start]) {
+		start--
+	}
+	if start == 0 || utf8.FullRune(data[start:max]) {
+		return max
+	}
+	return start
+}
+
+// holdBack returns a copy of the data to hold back, so that the buffer of
+// a long write is not retained.
+func holdBack(data []byte) []byte {
+	if len(data) == 0 {
+		return nil
+	}
+	return append([]byte(nil), data...)
+}
+
+// Flush sends any incomplete line that is being held back.
+func (w *Writer) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	line := w.partial
+	w.partial = nil
+	return errors.Trace(w.send(line))
+}
+
+func (w *Writer) send(line []byte) error {
+	line = bytes.TrimSuffix(line, []byte("\r"))
+	if len(line) == 0 {
+		return nil
+	}
+
+	msg := Message{
+		Header:         w.template,
+		StructuredData: w.structuredData,
+		Msg:            string(line),
+	}
+	msg.Timestamp = Timestamp{time.Now()}
+	return w.sender.Send(msg)
+}
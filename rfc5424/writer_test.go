@@ -0,0 +1,179 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package rfc5424_test
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/rfc/v2/rfc5424"
+)
+
+type WriterSuite struct {
+	testing.IsolationSuite
+
+	sender *recordingSender
+	header rfc5424.Header
+}
+
+var _ = gc.Suite(&WriterSuite{})
+
+func (s *WriterSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.sender = &recordingSender{}
+	s.header = rfc5424.Header{
+		Priority: rfc5424.Priority{
+			Severity: rfc5424.SeverityNotice,
+			Facility: rfc5424.FacilityLocal3,
+		},
+		AppName: "an-app",
+	}
+}
+
+func (s *WriterSuite) TestLogger(c *gc.C) {
+	w := rfc5424.NewWriter(s.sender, s.header)
+	w.SetStructuredData(rfc5424.StructuredData{
+		newStubElement(&testing.Stub{}, "spam", "x=y"),
+	})
+	logger := log.New(w, "prefix: ", 0)
+
+	logger.Print("one")
+	logger.Printf("two\nthree")
+
+	c.Assert(s.sender.messages, gc.HasLen, 3)
+	for i, text := range []string{"prefix: one", "prefix: two", "three"} {
+		msg := s.sender.messages[i]
+		c.Check(msg.Msg, gc.Equals, text)
+		c.Check(msg.Priority, jc.DeepEquals, s.header.Priority)
+		c.Check(msg.AppName, gc.Equals, s.header.AppName)
+		c.Check(msg.Timestamp.IsZero(), jc.IsFalse)
+		c.Check(msg.StructuredData.String(), gc.Equals, `[spam x="y"]`)
+	}
+}
+
+func (s *WriterSuite) TestPartialLines(c *gc.C) {
+	w := rfc5424.NewWriter(s.sender, s.header)
+
+	n, err := fmt.Fprint(w, "one\r\n\ntw")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(n, gc.Equals, 8)
+	fmt.Fprint(w, "o\nthr")
+
+	c.Assert(s.sender.messages, gc.HasLen, 2)
+	c.Check(s.sender.messages[0].Msg, gc.Equals, "one")
+	c.Check(s.sender.messages[1].Msg, gc.Equals, "two")
+
+	err = w.Flush()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.sender.messages, gc.HasLen, 3)
+	c.Check(s.sender.messages[2].Msg, gc.Equals, "thr")
+}
+
+func (s *WriterSuite) TestNoSplitLines(c *gc.C) {
+	w := rfc5424.NewWriter(s.sender, s.header)
+	w.SetSplitLines(false)
+
+	fmt.Fprint(w, "one\ntwo\n")
+
+	c.Assert(s.sender.messages, gc.HasLen, 1)
+	c.Check(s.sender.messages[0].Msg, gc.Equals, "one\ntwo")
+}
+
+func (s *WriterSuite) TestSendError(c *gc.C) {
+	s.sender.err = fmt.Errorf("boom")
+	w := rfc5424.NewWriter(s.sender, s.header)
+
+	n, err := fmt.Fprint(w, "one\n")
+
+	c.Check(err, gc.ErrorMatches, "boom")
+	c.Check(n, gc.Equals, 0)
+}
+
+func (s *WriterSuite) TestSendErrorPartWay(c *gc.C) {
+	s.sender.failOn = "two"
+	w := rfc5424.NewWriter(s.sender, s.header)
+	data := []byte("one\ntwo\nthree\nfo")
+
+	n, err := w.Write(data)
+	c.Check(err, gc.ErrorMatches, "send failed")
+	c.Check(n, gc.Equals, 4)
+	c.Assert(s.sender.messages, gc.HasLen, 1)
+
+	s.sender.failOn = ""
+	n, err = w.Write(data[n:])
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(n, gc.Equals, len(data)-4)
+	c.Assert(w.Flush(), jc.ErrorIsNil)
+	c.Assert(s.sender.messages, gc.HasLen, 4)
+	for i, text := range []string{"one", "two", "three", "fo"} {
+		c.Check(s.sender.messages[i].Msg, gc.Equals, text)
+	}
+}
+
+func (s *WriterSuite) TestSendErrorHeldBack(c *gc.C) {
+	w := rfc5424.NewWriter(s.sender, s.header)
+	fmt.Fprint(w, "o")
+	s.sender.failOn = "one"
+
+	n, err := fmt.Fprint(w, "ne\ntwo\n")
+	c.Check(err, gc.ErrorMatches, "send failed")
+	c.Check(n, gc.Equals, 0)
+
+	s.sender.failOn = ""
+	fmt.Fprint(w, "ne\ntwo\n")
+	c.Assert(s.sender.messages, gc.HasLen, 2)
+	c.Check(s.sender.messages[0].Msg, gc.Equals, "one")
+	c.Check(s.sender.messages[1].Msg, gc.Equals, "two")
+}
+
+func (s *WriterSuite) TestMaxLineLength(c *gc.C) {
+	w := rfc5424.NewWriter(s.sender, s.header)
+	w.SetMaxLineLength(4)
+
+	fmt.Fprint(w, "abcdefghij")
+	fmt.Fprint(w, "k\nab\n")
+
+	c.Assert(s.sender.messages, gc.HasLen, 4)
+	for i, text := range []string{"abcd", "efgh", "ijk", "ab"} {
+		c.Check(s.sender.messages[i].Msg, gc.Equals, text)
+	}
+}
+
+func (s *WriterSuite) TestMaxLineLengthMultiByte(c *gc.C) {
+	w := rfc5424.NewWriter(s.sender, s.header)
+	w.SetMaxLineLength(4)
+
+	// "é" and "€" are encoded as two and three bytes.
+	fmt.Fprint(w, "abé€")
+	fmt.Fprint(w, "\xe2\x82")
+	fmt.Fprint(w, "\xacd\n")
+
+	c.Assert(s.sender.messages, gc.HasLen, 3)
+	for i, text := range []string{"abé", "€", "€d"} {
+		c.Check(s.sender.messages[i].Msg, gc.Equals, text)
+	}
+}
+
+type recordingSender struct {
+	messages []rfc5424.Message
+	err      error
+
+	// failOn makes sending a message with that text fail.
+	failOn string
+}
+
+func (s *recordingSender) Send(msg rfc5424.Message) error {
+	if s.err != nil {
+		return s.err
+	}
+	if s.failOn != "" && msg.Msg == s.failOn {
+		return fmt.Errorf("send failed")
+	}
+	s.messages = append(s.messages, msg)
+	return nil
+}